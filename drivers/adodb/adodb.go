@@ -17,6 +17,7 @@ func init() {
 	drivers.Register("adodb", drivers.Driver{
 		AllowMultilineComments: true,
 		AllowCComments:         true,
+		AllowBrackets:          true,
 		RowsAffected: func(res sql.Result) (int64, error) {
 			return 0, nil
 		},
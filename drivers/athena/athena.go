@@ -15,6 +15,7 @@ func init() {
 	endRE := regexp.MustCompile(`;?\s*$`)
 	drivers.Register("awsathena", drivers.Driver{
 		AllowMultilineComments: true,
+		AllowBackticks:         true,
 		Process: func(prefix string, sqlstr string) (string, string, bool, error) {
 			sqlstr = endRE.ReplaceAllString(sqlstr, "")
 			typ, q := drivers.QueryExecType(prefix, sqlstr)
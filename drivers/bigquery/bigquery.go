@@ -9,5 +9,8 @@ import (
 )
 
 func init() {
-	drivers.Register("bigquery", drivers.Driver{})
+	drivers.Register("bigquery", drivers.Driver{
+		AllowBackslashEscapes: true,
+		AllowBackticks:        true,
+	})
 }
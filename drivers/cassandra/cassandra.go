@@ -18,6 +18,7 @@ import (
 	"github.com/gocql/gocql"
 	"github.com/xo/dburl"
 	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/completer"
 )
 
 func init() {
@@ -84,9 +85,47 @@ func init() {
 			}
 			return string(buf), nil
 		},
+		NewMetadataReader: NewMetadataReader,
 		BatchQueryPrefixes: map[string]string{
 			"BEGIN BATCH": "APPLY BATCH",
 		},
+		Dialect: completer.Dialect{
+			StartCommands: []string{
+				"APPLY BATCH",
+				"BEGIN BATCH",
+				"TRUNCATE",
+				"USE",
+			},
+			Commands: []string{
+				"ALLOW FILTERING",
+				"IF EXISTS",
+				"IF NOT EXISTS",
+				"USING TIMESTAMP",
+				"USING TTL",
+			},
+			Functions: []string{
+				"COUNT",
+				"MAX",
+				"MAXTIMEUUID",
+				"MIN",
+				"MINTIMEUUID",
+				"NOW",
+				"SUM",
+				"TODATE",
+				"TOKEN",
+				"TOTIMESTAMP",
+				"TTL",
+				"UUID",
+				"WRITETIME",
+			},
+			Clauses: []completer.Clause{
+				{Statement: "SELECT", After: []string{"=|<|>|<=|>=|IN|CONTAINS", "*"}, Keywords: []string{"ALLOW FILTERING"}},
+				{Statement: "SELECT", After: []string{"LIMIT", "*"}, Keywords: []string{"ALLOW FILTERING"}},
+				{Statement: "INSERT", After: []string{"*)"}, Keywords: []string{"IF NOT EXISTS", "USING TTL"}},
+				{Statement: "UPDATE", After: []string{"UPDATE", "*"}, Keywords: []string{"USING TTL", "USING TIMESTAMP"}},
+				{Statement: "DELETE|UPDATE", After: []string{"=|<|>|<=|>=|IN", "*"}, Keywords: []string{"IF EXISTS"}},
+			},
+		},
 	})
 }
 
@@ -0,0 +1,412 @@
+package cassandra
+
+import (
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/metadata"
+)
+
+type MetadataReader struct {
+	metadata.LoggingReader
+}
+
+// NewMetadataReader creates the metadata reader for Cassandra clusters,
+// reading keyspaces as schemas from system_schema.
+func NewMetadataReader(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
+	return &MetadataReader{
+		LoggingReader: metadata.NewLoggingReader(db, opts...),
+	}
+}
+
+var (
+	_ metadata.BasicReader       = &MetadataReader{}
+	_ metadata.IndexReader       = &MetadataReader{}
+	_ metadata.IndexColumnReader = &MetadataReader{}
+	_ metadata.PartitionReader   = &MetadataReader{}
+)
+
+func (r MetadataReader) Schemas(f metadata.Filter) (*metadata.SchemaSet, error) {
+	rows, closeRows, err := r.query(`SELECT keyspace_name FROM system_schema.keyspaces`, metadata.Filter{})
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	match := schemaMatcher(f)
+	matchName := metadata.LikeMatcher(f.Name)
+	var results []metadata.Schema
+	for rows.Next() {
+		var rec metadata.Schema
+		if err := rows.Scan(&rec.Schema); err != nil {
+			return nil, err
+		}
+		if !match(rec.Schema) || !matchName(rec.Schema) {
+			continue
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Schema < results[j].Schema
+	})
+	return metadata.NewSchemaSet(results), nil
+}
+
+// Tables lists tables and materialized views of keyspaces.
+func (r MetadataReader) Tables(f metadata.Filter) (*metadata.TableSet, error) {
+	var results []metadata.Table
+	for _, q := range []struct {
+		qstr, typ string
+	}{
+		{`SELECT keyspace_name, table_name, comment FROM system_schema.tables`, "TABLE"},
+		{`SELECT keyspace_name, view_name, comment FROM system_schema.views`, "MATERIALIZED VIEW"},
+	} {
+		tables, err := r.tables(q.qstr, q.typ, f)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, tables...)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Schema != results[j].Schema {
+			return results[i].Schema < results[j].Schema
+		}
+		return results[i].Name < results[j].Name
+	})
+	return metadata.NewTableSet(results), nil
+}
+
+func (r MetadataReader) tables(qstr, typ string, f metadata.Filter) ([]metadata.Table, error) {
+	rows, closeRows, err := r.query(qstr, f)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	match := schemaMatcher(f)
+	matchName := metadata.LikeMatcher(f.Name)
+	var results []metadata.Table
+	for rows.Next() {
+		rec := metadata.Table{Type: typ}
+		if err := rows.Scan(&rec.Schema, &rec.Name, &rec.Comment); err != nil {
+			return nil, err
+		}
+		if isSystem(rec.Schema) {
+			rec.Type = "SYSTEM " + typ
+		}
+		if !match(rec.Schema) || !matchName(rec.Name) || !metadata.HasType(f.Types, rec.Type) {
+			continue
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return results, nil
+}
+
+// Columns lists columns of tables in the order of their definition: the
+// partition key, clustering columns, then static and regular columns by name.
+func (r MetadataReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
+	cols, err := r.columns(f)
+	if err != nil {
+		return nil, err
+	}
+	matchName := metadata.LikeMatcher(f.Name)
+	var results []metadata.Column
+	for _, col := range cols {
+		if !matchName(col.name) {
+			continue
+		}
+		rec := metadata.Column{
+			Schema:          col.keyspace,
+			Table:           col.table,
+			Name:            col.name,
+			OrdinalPosition: col.ordinal,
+			DataType:        col.typ,
+			IsNullable:      metadata.YES,
+		}
+		if col.kind == "partition_key" || col.kind == "clustering" {
+			rec.IsNullable = metadata.NO
+		}
+		results = append(results, rec)
+	}
+	return metadata.NewColumnSet(results), nil
+}
+
+// column is a row of system_schema.columns.
+type column struct {
+	keyspace string
+	table    string
+	name     string
+	kind     string
+	position int
+	order    string
+	typ      string
+	ordinal  int
+}
+
+// kinds of columns, by their order in the primary key and table definition.
+var kinds = map[string]int{
+	"partition_key": 0,
+	"clustering":    1,
+	"static":        2,
+	"regular":       3,
+}
+
+func (r MetadataReader) columns(f metadata.Filter) ([]column, error) {
+	qstr := `SELECT keyspace_name, table_name, column_name, kind, position, clustering_order, type FROM system_schema.columns`
+	rows, closeRows, err := r.query(qstr, f)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	match := schemaMatcher(f)
+	matchParent := metadata.LikeMatcher(f.Parent)
+	var cols []column
+	for rows.Next() {
+		var col column
+		if err := rows.Scan(&col.keyspace, &col.table, &col.name, &col.kind, &col.position, &col.order, &col.typ); err != nil {
+			return nil, err
+		}
+		if !match(col.keyspace) || !matchParent(col.table) {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	sort.SliceStable(cols, func(i, j int) bool {
+		a, b := cols[i], cols[j]
+		switch {
+		case a.keyspace != b.keyspace:
+			return a.keyspace < b.keyspace
+		case a.table != b.table:
+			return a.table < b.table
+		case kinds[a.kind] != kinds[b.kind]:
+			return kinds[a.kind] < kinds[b.kind]
+		case a.position != b.position:
+			return a.position < b.position
+		}
+		return a.name < b.name
+	})
+	for i := range cols {
+		cols[i].ordinal = 1
+		if i != 0 && cols[i-1].keyspace == cols[i].keyspace && cols[i-1].table == cols[i].table {
+			cols[i].ordinal = cols[i-1].ordinal + 1
+		}
+	}
+	return cols, nil
+}
+
+// Partitions returns the partition key of tables, without any named
+// partitions, since rows are distributed by the token of the partition key.
+func (r MetadataReader) Partitions(f metadata.Filter) (*metadata.PartitionSet, error) {
+	cols, err := r.columns(f)
+	if err != nil {
+		return nil, err
+	}
+	var results []metadata.Partition
+	for _, col := range cols {
+		if col.kind != "partition_key" {
+			continue
+		}
+		if n := len(results); n != 0 && results[n-1].Schema == col.keyspace && results[n-1].Table == col.table {
+			results[n-1].Key += ", " + col.name
+			continue
+		}
+		results = append(results, metadata.Partition{
+			Schema:   col.keyspace,
+			Table:    col.table,
+			Strategy: "HASH",
+			Key:      col.name,
+		})
+	}
+	return metadata.NewPartitionSet(results), nil
+}
+
+// Indexes returns the primary key of tables, with the clustering order as its
+// type, and secondary indexes.
+func (r MetadataReader) Indexes(f metadata.Filter) (*metadata.IndexSet, error) {
+	cols, err := r.columns(metadata.Filter{Schema: f.Schema, Parent: f.Parent, WithSystem: f.WithSystem})
+	if err != nil {
+		return nil, err
+	}
+	matchName := metadata.LikeMatcher(f.Name)
+	var indexes []metadata.Index
+	var orders []string
+	for _, col := range cols {
+		name := col.table + "_pkey"
+		if (col.kind != "partition_key" && col.kind != "clustering") || !matchName(name) {
+			continue
+		}
+		if n := len(indexes); n == 0 || indexes[n-1].Schema != col.keyspace || indexes[n-1].Name != name {
+			indexes = append(indexes, metadata.Index{
+				Schema:    col.keyspace,
+				Table:     col.table,
+				Name:      name,
+				IsPrimary: metadata.YES,
+				IsUnique:  metadata.YES,
+			})
+			orders = nil
+		}
+		if col.kind == "clustering" {
+			orders = append(orders, col.name+" "+strings.ToUpper(col.order))
+			indexes[len(indexes)-1].Type = "CLUSTERING ORDER BY (" + strings.Join(orders, ", ") + ")"
+		}
+	}
+	secondary, err := r.indexes(f)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range secondary {
+		indexes = append(indexes, idx.Index)
+	}
+	return metadata.NewIndexSet(indexes), nil
+}
+
+// index is a row of system_schema.indexes.
+type index struct {
+	metadata.Index
+	target string
+}
+
+func (r MetadataReader) indexes(f metadata.Filter) ([]index, error) {
+	qstr := `SELECT keyspace_name, table_name, index_name, kind, options FROM system_schema.indexes`
+	rows, closeRows, err := r.query(qstr, f)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	match := schemaMatcher(f)
+	matchParent, matchName := metadata.LikeMatcher(f.Parent), metadata.LikeMatcher(f.Name)
+	var results []index
+	for rows.Next() {
+		rec := index{Index: metadata.Index{IsPrimary: metadata.NO, IsUnique: metadata.NO}}
+		var options interface{}
+		if err := rows.Scan(&rec.Schema, &rec.Table, &rec.Name, &rec.Type, &options); err != nil {
+			return nil, err
+		}
+		if !match(rec.Schema) || !matchParent(rec.Table) || !matchName(rec.Name) {
+			continue
+		}
+		opts := optionsMap(options)
+		if class := opts["class_name"]; rec.Type == "CUSTOM" && class != "" {
+			rec.Type = class
+		}
+		rec.target = opts["target"]
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch {
+		case a.Schema != b.Schema:
+			return a.Schema < b.Schema
+		case a.Table != b.Table:
+			return a.Table < b.Table
+		}
+		return a.Name < b.Name
+	})
+	return results, nil
+}
+
+// IndexColumns returns the partition key and clustering columns of primary
+// keys, and the targets of secondary indexes.
+func (r MetadataReader) IndexColumns(f metadata.Filter) (*metadata.IndexColumnSet, error) {
+	cols, err := r.columns(metadata.Filter{Schema: f.Schema, Parent: f.Parent, WithSystem: f.WithSystem})
+	if err != nil {
+		return nil, err
+	}
+	matchName := metadata.LikeMatcher(f.Name)
+	types := make(map[[3]string]string)
+	var results []metadata.IndexColumn
+	for _, col := range cols {
+		types[[3]string{col.keyspace, col.table, col.name}] = col.typ
+		if (col.kind != "partition_key" && col.kind != "clustering") || !matchName(col.table+"_pkey") {
+			continue
+		}
+		results = append(results, metadata.IndexColumn{
+			Schema:          col.keyspace,
+			Table:           col.table,
+			IndexName:       col.table + "_pkey",
+			Name:            col.name,
+			DataType:        col.typ,
+			OrdinalPosition: col.ordinal,
+		})
+	}
+	secondary, err := r.indexes(f)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range secondary {
+		name := idx.target
+		// targets of collections are wrapped, ie values(tags)
+		if i := strings.IndexByte(name, '('); i != -1 && strings.HasSuffix(name, ")") {
+			name = name[i+1 : len(name)-1]
+		}
+		if s, err := strconv.Unquote(name); err == nil {
+			name = s
+		}
+		results = append(results, metadata.IndexColumn{
+			Schema:          idx.Schema,
+			Table:           idx.Table,
+			IndexName:       idx.Name,
+			Name:            idx.target,
+			DataType:        types[[3]string{idx.Schema, idx.Table, name}],
+			OrdinalPosition: 1,
+		})
+	}
+	return metadata.NewIndexColumnSet(results), nil
+}
+
+// query runs the query, restricted to a single keyspace when the filter's
+// schema has no wildcards, since system_schema tables can only be filtered by
+// their partition key.
+func (r MetadataReader) query(qstr string, f metadata.Filter) (*sql.Rows, func(), error) {
+	if f.Schema != "" && !strings.ContainsAny(f.Schema, "%_") {
+		return r.Query(qstr+` WHERE keyspace_name = ?`, f.Schema)
+	}
+	return r.Query(qstr)
+}
+
+// schemaMatcher returns a func matching keyspaces of the filter. CQL does not
+// have catalogs, so nothing matches filters qualified with one.
+func schemaMatcher(f metadata.Filter) func(string) bool {
+	if f.Catalog != "" {
+		return func(string) bool { return false }
+	}
+	match := metadata.LikeMatcher(f.Schema)
+	return func(keyspace string) bool {
+		return match(keyspace) && (f.WithSystem || f.Schema != "" || !isSystem(keyspace))
+	}
+}
+
+// isSystem returns true for keyspaces managed by Cassandra or Scylla.
+func isSystem(keyspace string) bool {
+	return keyspace == "system" || strings.HasPrefix(keyspace, "system_") || strings.HasPrefix(keyspace, "dse_")
+}
+
+// optionsMap converts the map returned for a map<text, text> column.
+func optionsMap(v interface{}) map[string]string {
+	switch m := v.(type) {
+	case map[string]string:
+		return m
+	case map[string]interface{}:
+		opts := make(map[string]string, len(m))
+		for k, v := range m {
+			if s, ok := v.(string); ok {
+				opts[k] = s
+			}
+		}
+		return opts
+	}
+	return nil
+}
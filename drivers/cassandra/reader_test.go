@@ -0,0 +1,213 @@
+package cassandra
+
+import (
+	"database/sql/driver"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/drivers/metadata/metadatatest"
+)
+
+func newReader(t *testing.T) (*MetadataReader, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock := metadatatest.NewDB(t, valueConverter{})
+	return NewMetadataReader(db).(*MetadataReader), mock
+}
+
+// valueConverter passes through maps, like the cql driver returns for
+// map<text, text> columns.
+type valueConverter struct{}
+
+func (valueConverter) ConvertValue(v interface{}) (driver.Value, error) {
+	if m, ok := v.(map[string]string); ok {
+		return m, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
+
+// expectColumns expects a query of system_schema.columns, returning columns
+// of the film table, in the order of their names like Cassandra does.
+func expectColumns(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery {
+	return mock.ExpectQuery(`FROM system_schema\.columns`).
+		WillReturnRows(mock.NewRows([]string{"keyspace_name", "table_name", "column_name", "kind", "position", "clustering_order", "type"}).
+			AddRow("sakila", "film", "language", "partition_key", 1, "none", "text").
+			AddRow("sakila", "film", "length", "regular", -1, "none", "int").
+			AddRow("sakila", "film", "release_year", "partition_key", 0, "none", "int").
+			AddRow("sakila", "film", "tags", "regular", -1, "none", "set<text>").
+			AddRow("sakila", "film", "title", "clustering", 1, "asc", "text").
+			AddRow("sakila", "film", "rating", "clustering", 0, "desc", "float").
+			AddRow("system", "local", "key", "partition_key", 0, "none", "text"))
+}
+
+func TestSchemas(t *testing.T) {
+	r, mock := newReader(t)
+	rows := func() *sqlmock.Rows {
+		return mock.NewRows([]string{"keyspace_name"}).
+			AddRow("system_schema").
+			AddRow("sakila").
+			AddRow("system").
+			AddRow("inventory")
+	}
+	tests := []struct {
+		filter metadata.Filter
+		exp    string
+	}{
+		{metadata.Filter{}, "inventory, sakila"},
+		{metadata.Filter{WithSystem: true}, "inventory, sakila, system, system_schema"},
+		{metadata.Filter{Name: "s%"}, "sakila"},
+		{metadata.Filter{Catalog: "sakila", Name: "f%", WithSystem: true}, ""},
+	}
+	for i, test := range tests {
+		mock.ExpectQuery(`SELECT keyspace_name FROM system_schema\.keyspaces$`).WillReturnRows(rows())
+		res, err := r.Schemas(test.filter)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		var names []string
+		for res.Next() {
+			names = append(names, res.Get().Schema)
+		}
+		if s := strings.Join(names, ", "); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+}
+
+func TestTables(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM system_schema\.tables WHERE keyspace_name = \?`).
+		WithArgs("sakila").
+		WillReturnRows(mock.NewRows([]string{"keyspace_name", "table_name", "comment"}).
+			AddRow("sakila", "film", "films").
+			AddRow("sakila", "actor", ""))
+	mock.ExpectQuery(`FROM system_schema\.views WHERE keyspace_name = \?`).
+		WithArgs("sakila").
+		WillReturnRows(mock.NewRows([]string{"keyspace_name", "view_name", "comment"}).
+			AddRow("sakila", "film_by_title", ""))
+	res, err := r.Tables(metadata.Filter{Schema: "sakila"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		names = append(names, res.Get().Schema+"."+res.Get().Name+":"+res.Get().Type)
+	}
+	exp := "sakila.actor:TABLE, sakila.film:TABLE, sakila.film_by_title:MATERIALIZED VIEW"
+	if s := strings.Join(names, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestTableFilters(t *testing.T) {
+	r, mock := newReader(t)
+	tests := []struct {
+		filter metadata.Filter
+		exp    string
+	}{
+		{metadata.Filter{}, "sakila.actor:TABLE, sakila.film_by_title:MATERIALIZED VIEW"},
+		{metadata.Filter{WithSystem: true}, "sakila.actor:TABLE, sakila.film_by_title:MATERIALIZED VIEW, system.local:SYSTEM TABLE"},
+		{metadata.Filter{Types: []string{"TABLE"}, WithSystem: true}, "sakila.actor:TABLE"},
+		{metadata.Filter{Types: []string{"SYSTEM TABLE"}, WithSystem: true}, "system.local:SYSTEM TABLE"},
+		{metadata.Filter{Name: "film%", WithSystem: true}, "sakila.film_by_title:MATERIALIZED VIEW"},
+	}
+	for i, test := range tests {
+		mock.ExpectQuery(`FROM system_schema\.tables$`).
+			WillReturnRows(mock.NewRows([]string{"keyspace_name", "table_name", "comment"}).
+				AddRow("sakila", "actor", "").
+				AddRow("system", "local", ""))
+		mock.ExpectQuery(`FROM system_schema\.views$`).
+			WillReturnRows(mock.NewRows([]string{"keyspace_name", "view_name", "comment"}).
+				AddRow("sakila", "film_by_title", ""))
+		res, err := r.Tables(test.filter)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		var names []string
+		for res.Next() {
+			names = append(names, res.Get().Schema+"."+res.Get().Name+":"+res.Get().Type)
+		}
+		if s := strings.Join(names, ", "); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	r, mock := newReader(t)
+	expectColumns(mock)
+	res, err := r.Columns(metadata.Filter{Parent: "film"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var cols []string
+	for res.Next() {
+		c := res.Get()
+		cols = append(cols, strconv.Itoa(c.OrdinalPosition)+" "+c.Name+" "+c.DataType+" "+string(c.IsNullable))
+	}
+	exp := "1 release_year int NO, 2 language text NO, 3 rating float NO, 4 title text NO, 5 length int YES, 6 tags set<text> YES"
+	if s := strings.Join(cols, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	r, mock := newReader(t)
+	expectColumns(mock)
+	res, err := r.Partitions(metadata.Filter{WithSystem: true})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var keys []string
+	for res.Next() {
+		p := res.Get()
+		keys = append(keys, p.Schema+"."+p.Table+":"+p.Strategy+" ("+p.Key+")")
+	}
+	if s, exp := strings.Join(keys, ", "), "sakila.film:HASH (release_year, language), system.local:HASH (key)"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	r, mock := newReader(t)
+	expectColumns(mock)
+	mock.ExpectQuery(`FROM system_schema\.indexes`).
+		WillReturnRows(mock.NewRows([]string{"keyspace_name", "table_name", "index_name", "kind", "options"}).
+			AddRow("sakila", "film", "film_tags_idx", "COMPOSITES", map[string]string{"target": "values(tags)"}))
+	res, err := r.Indexes(metadata.Filter{Parent: "film"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		i := res.Get()
+		names = append(names, i.Name+":"+string(i.IsPrimary)+":"+i.Type)
+	}
+	exp := "film_pkey:YES:CLUSTERING ORDER BY (rating DESC, title ASC), film_tags_idx:NO:COMPOSITES"
+	if s := strings.Join(names, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestIndexColumns(t *testing.T) {
+	r, mock := newReader(t)
+	expectColumns(mock)
+	mock.ExpectQuery(`FROM system_schema\.indexes`).
+		WillReturnRows(mock.NewRows([]string{"keyspace_name", "table_name", "index_name", "kind", "options"}).
+			AddRow("sakila", "film", "film_tags_idx", "COMPOSITES", map[string]string{"target": "values(tags)"}))
+	res, err := r.IndexColumns(metadata.Filter{Parent: "film"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var cols []string
+	for res.Next() {
+		c := res.Get()
+		cols = append(cols, c.IndexName+"."+c.Name+":"+c.DataType)
+	}
+	exp := "film_pkey.release_year:int, film_pkey.language:text, film_pkey.rating:float, film_pkey.title:text, film_tags_idx.values(tags):set<text>"
+	if s := strings.Join(cols, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
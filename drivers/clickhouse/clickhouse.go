@@ -8,15 +8,76 @@ import (
 
 	_ "github.com/ClickHouse/clickhouse-go/v2" // DRIVER
 	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/completer"
 )
 
 func init() {
 	drivers.Register("clickhouse", drivers.Driver{
 		AllowMultilineComments: true,
+		AllowBackslashEscapes:  true,
+		AllowBackticks:         true,
 		RowsAffected: func(sql.Result) (int64, error) {
 			return 0, nil
 		},
 		Copy:              drivers.CopyWithInsert(func(int) string { return "?" }),
 		NewMetadataReader: NewMetadataReader,
+		Dialect: completer.Dialect{
+			// there is no ClickHouse lexer, so list keywords of common
+			// commands supported by ClickHouse
+			Keywords: []string{
+				"ALTER", "AND", "BY", "CASE", "CREATE", "CROSS", "DELETE",
+				"DESC", "DESCRIBE", "DROP", "ELSE", "END", "EXPLAIN", "FROM",
+				"FULL", "GRANT", "GROUP", "HAVING", "IN", "INNER", "INSERT",
+				"IS", "JOIN", "LEFT", "LIMIT", "NOT", "NULL", "ON", "OR",
+				"ORDER", "OUTER", "REVOKE", "SELECT", "SET", "SHOW", "THEN",
+				"TRUNCATE", "WHEN", "WHERE", "WITH",
+			},
+			StartCommands: []string{
+				"ATTACH",
+				"CHECK TABLE",
+				"DETACH",
+				"EXISTS",
+				"KILL QUERY",
+				"OPTIMIZE TABLE",
+				"RENAME TABLE",
+				"SYSTEM",
+				"USE",
+			},
+			Commands: []string{
+				"ARRAY JOIN",
+				"FINAL",
+				"FORMAT",
+				"GLOBAL IN",
+				"LIMIT BY",
+				"PREWHERE",
+				"SAMPLE",
+				"SETTINGS",
+			},
+			Functions: []string{
+				"any",
+				"arrayJoin",
+				"avg",
+				"count",
+				"countIf",
+				"groupArray",
+				"if",
+				"max",
+				"min",
+				"now",
+				"quantile",
+				"sum",
+				"sumIf",
+				"toDate",
+				"toDateTime",
+				"toStartOfDay",
+				"uniq",
+				"uniqExact",
+			},
+			Clauses: []completer.Clause{
+				{Statement: "SELECT", After: []string{"FROM", "*"}, Keywords: []string{"ARRAY JOIN", "FINAL", "PREWHERE", "SAMPLE"}},
+				{Statement: "SELECT", After: []string{"LIMIT", "*"}, Keywords: []string{"BY", "OFFSET"}},
+				{Statement: "SELECT", After: []string{"LIMIT", "*", "BY", "*"}, Keywords: []string{"LIMIT"}},
+			},
+		},
 	})
 }
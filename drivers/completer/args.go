@@ -0,0 +1,271 @@
+package completer
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/env"
+)
+
+// ArgKind is a kind of backslash command argument.
+type ArgKind uint
+
+// Argument kinds.
+const (
+	// ArgNone is an argument that is not completed.
+	ArgNone ArgKind = iota
+	// ArgVariable is the name of a variable.
+	ArgVariable
+	// ArgIsolationLevel is a transaction isolation level.
+	ArgIsolationLevel
+	// ArgDuration is a duration.
+	ArgDuration
+	// ArgTable is a table, optionally followed by a list of its columns, on
+	// the connection of the last preceding ArgConnection, if any.
+	ArgTable
+	// ArgColumns is a list of columns of tables referenced by the query.
+	ArgColumns
+	// ArgFormatOption is a parenthesized list of format options, ie
+	// (format=csv).
+	ArgFormatOption
+	// ArgConnection is a database url or connection string.
+	ArgConnection
+	// ArgFile is a file name.
+	ArgFile
+)
+
+// WithArgKinds option, setting kinds of arguments of backslash commands, by
+// command name, without the leading backslash.
+func WithArgKinds(kinds map[string][]ArgKind) Option {
+	return func(c *completer) {
+		c.argKinds = kinds
+	}
+}
+
+// WithConnReader option, setting the func returning a metadata reader of a
+// database url, used to complete tables of other connections.
+func WithConnReader(f func(string) metadata.Reader) Option {
+	return func(c *completer) {
+		c.connReader = f
+	}
+}
+
+// isolationLevels completed for ArgIsolationLevel.
+var isolationLevels = []string{
+	"default",
+	"linearizable",
+	"read-committed",
+	"read-uncommitted",
+	"repeatable-read",
+	"serializable",
+	"snapshot",
+	"write-committed",
+}
+
+// durations completed for ArgDuration.
+var durations = []string{"1s", "2s", "5s", "10s", "30s", "1m"}
+
+// formatOptionValues are values of format options.
+var formatOptionValues = map[string][]string{
+	"expanded":                 {"auto", "on", "off"},
+	"fieldsep_zero":            {"on", "off"},
+	"footer":                   {"on", "off"},
+	"format":                   {"unaligned", "aligned", "wrapped", "html", "asciidoc", "latex", "latex-longtable", "troff-ms", "csv", "json", "vertical"},
+	"linestyle":                {"ascii", "old-ascii", "unicode"},
+	"numericlocale":            {"on", "off"},
+	"pager":                    {"always", "on", "off"},
+	"recordsep_zero":           {"on", "off"},
+	"tuples_only":              {"on", "off"},
+	"unicode_border_linestyle": {"single", "double"},
+	"unicode_column_linestyle": {"single", "double"},
+	"unicode_header_linestyle": {"single", "double"},
+}
+
+// backslashArgs are arguments of a backslash command.
+type backslashArgs struct {
+	// name of the command, without the leading backslash
+	name string
+	// args preceding the completed one
+	args []string
+	// current is the unfinished argument, including the completed text
+	current string
+	// query preceding the command
+	query []rune
+}
+
+// findBackslashArgs returns arguments of the backslash command at the end of
+// line, or false if the end of line is not an argument of a command.
+func findBackslashArgs(line []rune) (backslashArgs, bool) {
+	var cmd backslashArgs
+	start := -1
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '\\' && (i == 0 || unicode.IsSpace(line[i-1])):
+			start = i
+		}
+	}
+	if start == -1 {
+		return cmd, false
+	}
+	end := start
+	for end < len(line) && !unicode.IsSpace(line[end]) {
+		end++
+	}
+	// still completing the command
+	if end == len(line) {
+		return cmd, false
+	}
+	cmd.name, cmd.query = string(line[start+1:end]), line[:start]
+	args, current := splitArgs(line[end:])
+	cmd.args, cmd.current = args, current
+	return cmd, true
+}
+
+// splitArgs splits s into space separated arguments, keeping quoted strings
+// and parenthesized lists in a single argument, and returns the unfinished
+// last argument separately.
+func splitArgs(s []rune) ([]string, string) {
+	var args []string
+	var arg []rune
+	var quote rune
+	depth, started := 0, false
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')' && depth != 0:
+			depth--
+		case unicode.IsSpace(r) && depth == 0:
+			if started {
+				args, arg, started = append(args, string(arg)), arg[:0], false
+			}
+			continue
+		}
+		arg, started = append(arg, r), true
+	}
+	return args, string(arg)
+}
+
+// completeBackslashArgs completes arguments of backslash commands having
+// declared argument kinds, or returns false to use other rules.
+func (c completer) completeBackslashArgs(line, text []rune) ([][]rune, bool) {
+	cmd, ok := findBackslashArgs(line)
+	if !ok {
+		return nil, false
+	}
+	kinds, ok := c.argKinds[cmd.name]
+	if !ok {
+		return nil, false
+	}
+	// skip flags, like -n or -read-only
+	var args []string
+	flags := false
+	for _, arg := range cmd.args {
+		if strings.HasPrefix(arg, "-") {
+			flags = true
+			continue
+		}
+		args = append(args, arg)
+	}
+	if len(kinds) != 0 && kinds[0] == ArgFormatOption {
+		switch {
+		case strings.HasPrefix(cmd.current, "(") && len(args) == 0:
+			return c.completeFormatOptions(cmd.current, text), true
+		case len(args) != 0 && strings.HasPrefix(args[0], "("):
+			args = args[1:]
+		}
+		kinds = kinds[1:]
+	}
+	if len(args) >= len(kinds) {
+		return nil, true
+	}
+	switch kinds[len(args)] {
+	case ArgVariable:
+		return completeFromVariables(text, "", "", false), true
+	case ArgIsolationLevel:
+		levels := isolationLevels
+		if !flags {
+			levels = append([]string{"-read-only"}, levels...)
+		}
+		return CompleteFromList(text, levels...), true
+	case ArgDuration:
+		return CompleteFromList(text, durations...), true
+	case ArgTable:
+		r := c.reader
+		for i := len(args) - 1; i >= 0; i-- {
+			if i < len(kinds) && kinds[i] == ArgConnection {
+				if c.connReader == nil {
+					return nil, true
+				}
+				if r = c.connReader(args[i]); r == nil {
+					return nil, true
+				}
+				break
+			}
+		}
+		o := completer{reader: r, logger: c.logger}
+		if i := strings.IndexRune(cmd.current, '('); i != -1 {
+			ref := parseTableName(cmd.current[:i])
+			// commas are not word breaks
+			text = text[strings.LastIndex(string(text), ",")+1:]
+			return CompleteFromList(text, o.getColumns(ref)...), true
+		}
+		return o.completeWithSelectables(text), true
+	case ArgColumns:
+		var names []string
+		for _, ref := range findTableRefs(cmd.query, len(cmd.query)) {
+			names = append(names, c.getColumns(ref)...)
+		}
+		return CompleteFromList(text, names...), true
+	case ArgConnection:
+		return CompleteFromList(text, c.connStrings...), true
+	case ArgFile:
+		return completeFromFiles(text), true
+	}
+	return nil, true
+}
+
+// completeFormatOptions completes names and values of format options in an
+// unfinished list of options.
+func (c completer) completeFormatOptions(options string, text []rune) [][]rune {
+	opt := options[strings.LastIndexFunc(options, unicode.IsSpace)+1:]
+	if i := strings.IndexRune(opt, '='); i != -1 {
+		return CompleteFromList(text, formatOptionValues[strings.TrimPrefix(opt[:i], "(")]...)
+	}
+	vars := env.Pall()
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return CompleteFromListCase(MATCH_CASE, text, names...)
+}
+
+// parseTableName parses a possibly qualified table name.
+func parseTableName(name string) tableRef {
+	var ref tableRef
+	parts := strings.Split(name, ".")
+	ref.name = parts[len(parts)-1]
+	if len(parts) > 1 {
+		ref.schema = parts[len(parts)-2]
+	}
+	if len(parts) > 2 {
+		ref.catalog = parts[len(parts)-3]
+	}
+	return ref
+}
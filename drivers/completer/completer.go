@@ -3,6 +3,7 @@ package completer
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -117,8 +118,7 @@ func NewDefaultCompleter(opts ...Option) readline.AutoCompleter {
 		reader:           struct{}{},
 		logger:           log.New(os.Stdout, "ERROR: ", log.LstdFlags),
 		sqlStartCommands: CommonSqlStartCommands,
-		// TODO do we need to add built-in functions like, COALESCE, CAST, NULLIF, CONCAT etc?
-		sqlCommands: CommonSqlCommands,
+		sqlCommands:      CommonSqlCommands,
 		backslashCommands: []string{
 			`\!`,
 			`\?`,
@@ -137,6 +137,24 @@ func NewDefaultCompleter(opts ...Option) readline.AutoCompleter {
 			`\da`,
 			`\daS+`,
 			`\daS`,
+			`\dco+`,
+			`\dco`,
+			`\dcoS+`,
+			`\dcoS`,
+			`\dD+`,
+			`\dD`,
+			`\dDS+`,
+			`\dDS`,
+			`\dE+`,
+			`\dE`,
+			`\dES+`,
+			`\dES`,
+			`\des+`,
+			`\des`,
+			`\deu+`,
+			`\deu`,
+			`\dew+`,
+			`\dew`,
 			`\df+`,
 			`\df`,
 			`\dfS+`,
@@ -153,6 +171,8 @@ func NewDefaultCompleter(opts ...Option) readline.AutoCompleter {
 			`\dn`,
 			`\dnS+`,
 			`\dnS`,
+			`\dP+`,
+			`\dP`,
 			`\drivers`,
 			`\ds+`,
 			`\ds`,
@@ -160,6 +180,10 @@ func NewDefaultCompleter(opts ...Option) readline.AutoCompleter {
 			`\dS`,
 			`\dsS+`,
 			`\dsS`,
+			`\dT+`,
+			`\dT`,
+			`\dTS+`,
+			`\dTS`,
 			`\dt+`,
 			`\dt`,
 			`\dtS+`,
@@ -168,6 +192,12 @@ func NewDefaultCompleter(opts ...Option) readline.AutoCompleter {
 			`\dv`,
 			`\dvS+`,
 			`\dvS`,
+			`\dx+`,
+			`\dx`,
+			`\dy+`,
+			`\dy`,
+			`\dyS+`,
+			`\dyS`,
 			`\e`,
 			`\echo`,
 			`\f`,
@@ -187,9 +217,11 @@ func NewDefaultCompleter(opts ...Option) readline.AutoCompleter {
 			`\q`,
 			`\r`,
 			`\raw`,
+			`\refresh`,
 			`\rollback`,
 			`\set`,
 			`\setenv`,
+			`\sig`,
 			`\t`,
 			`\T`,
 			`\timing`,
@@ -203,6 +235,9 @@ func NewDefaultCompleter(opts ...Option) readline.AutoCompleter {
 	for _, o := range opts {
 		o(&c)
 	}
+	if c.readerComplete != nil {
+		c.beforeComplete = c.readerComplete(c.reader)
+	}
 	return c
 }
 
@@ -258,6 +293,22 @@ func WithBeforeComplete(f CompleteFunc) Option {
 	}
 }
 
+// WithReaderBeforeComplete option, for a before complete func using the
+// reader of the completer
+func WithReaderBeforeComplete(f func(metadata.Reader) CompleteFunc) Option {
+	return func(c *completer) {
+		c.readerComplete = f
+	}
+}
+
+// WithHints option, for writing hints, like function signatures, that are
+// shown instead of being completed
+func WithHints(w io.Writer) Option {
+	return func(c *completer) {
+		c.hints = w
+	}
+}
+
 // completer based on https://github.com/postgres/postgres/blob/9f3665fbfc34b963933e51778c7feaa8134ac885/src/bin/psql/tab-complete.c
 type completer struct {
 	db                metadata.DB
@@ -265,9 +316,15 @@ type completer struct {
 	logger            logger
 	sqlStartCommands  []string
 	sqlCommands       []string
+	functions         []string
+	clauses           []Clause
 	backslashCommands []string
+	argKinds          map[string][]ArgKind
 	connStrings       []string
+	connReader        func(string) metadata.Reader
 	beforeComplete    CompleteFunc
+	readerComplete    func(metadata.Reader) CompleteFunc
+	hints             io.Writer
 }
 
 // CompleteFunc returns patterns completing current text, using previous words as context
@@ -290,6 +347,9 @@ func (c completer) Do(line []rune, start int) (newLine [][]rune, length int) {
 	}
 	previousWords := getPreviousWords(start, line)
 	text := line[i:start]
+	// ignore the current word, as it can be an incomplete table name
+	refs := findTableRefs(append(line[:i:i], line[start:]...), i)
+	call := findFunctionCall(line[:start])
 
 	if c.beforeComplete != nil {
 		result := c.beforeComplete(previousWords, text)
@@ -297,19 +357,29 @@ func (c completer) Do(line []rune, start int) (newLine [][]rune, length int) {
 			return result, len(text)
 		}
 	}
-	result := c.complete(previousWords, text)
+	if result, ok := c.completeBackslashArgs(line[:start], text); ok {
+		return result, len(text)
+	}
+	result := c.complete(previousWords, text, refs, call)
+	if hints := c.completeWithClauses(previousWords, text, statementKeyword(line[:i])); len(hints) != 0 {
+		result = append(hints, withoutCompletions(result, hints)...)
+	}
 	if result != nil {
 		return result, len(text)
 	}
 	return nil, 0
 }
 
-func (c completer) complete(previousWords []string, text []rune) [][]rune {
+func (c completer) complete(previousWords []string, text []rune, refs []tableRef, call *funcCall) [][]rune {
 	if len(text) > 0 {
 		if len(previousWords) == 0 && text[0] == '\\' {
 			/* If current word is a backslash command, offer completions for that */
 			return CompleteFromListCase(MATCH_CASE, text, c.backslashCommands...)
 		}
+		if i := strings.LastIndex(string(text), "::"); i != -1 {
+			/* If current word is a type cast, offer data types */
+			return c.completeWithDataTypes(text[i+2:])
+		}
 		if text[0] == ':' {
 			if len(text) == 1 || text[1] == ':' {
 				return nil
@@ -328,6 +398,14 @@ func (c completer) complete(previousWords []string, text []rune) [][]rune {
 		/* If no previous word, suggest one of the basic sql commands */
 		return CompleteFromList(text, c.sqlStartCommands...)
 	}
+	/* Complete columns qualified by a table or alias from FROM or JOIN clauses */
+	if i, qualifier := findQualifiedRef(refs, string(text)); i != -1 {
+		names := c.getColumns(refs[i])
+		for i, name := range names {
+			names[i] = qualifier + "." + name
+		}
+		return CompleteFromList(text, names...)
+	}
 	/* DELETE --- can be inside EXPLAIN, RULE, etc */
 	/* ... despite which, only complete DELETE with FROM at start of line */
 	if matches(IGNORE_CASE, previousWords, "DELETE") {
@@ -415,6 +493,28 @@ func (c completer) complete(previousWords []string, text []rune) [][]rune {
 	if TailMatches(IGNORE_CASE, previousWords, "UPDATE", "*", "SET", "!*=") {
 		return CompleteFromList(text, "=")
 	}
+	/* Complete JOIN ... ON with conditions from foreign keys */
+	if i := findJoinedRef(refs, previousWords); i != -1 {
+		return c.completeWithJoinConditions(text, refs, i)
+	}
+	/* Complete arguments of function calls, showing signatures of the function */
+	if call != nil {
+		if result := c.completeWithArguments(text, refs, call); result != nil {
+			return result
+		}
+	}
+	/* Complete columns of tables from FROM or JOIN clauses, in SELECT, WHERE, ON, etc */
+	if len(refs) != 0 && isColumnPosition(previousWords) {
+		return c.completeWithColumns(text, refs,
+			"AND",
+			"OR",
+			"CASE",
+			"WHEN",
+			"THEN",
+			"ELSE",
+			"END",
+		)
+	}
 	/* WHERE */
 	/* Simple case of the word before the where being the table name */
 	if TailMatches(IGNORE_CASE, previousWords, "*", "WHERE") {
@@ -431,6 +531,15 @@ func (c completer) complete(previousWords []string, text []rune) [][]rune {
 		)
 	}
 
+	/* Complete CAST(... AS with data types */
+	if isCastTarget(previousWords) {
+		return c.completeWithDataTypes(text)
+	}
+
+	/* Complete JOIN with tables related by foreign keys first */
+	if len(refs) != 0 && TailMatches(IGNORE_CASE, previousWords, "JOIN") {
+		return c.completeWithJoinables(text, refs)
+	}
 	/* ... FROM | JOIN ... */
 	if TailMatches(IGNORE_CASE, previousWords, "FROM|JOIN") {
 		return c.completeWithSelectables(text)
@@ -453,7 +562,7 @@ func (c completer) complete(previousWords []string, text []rune) [][]rune {
 	if TailMatches(MATCH_CASE, previousWords, `\da*`) {
 		return c.completeWithFunctions(text, []string{"AGGREGATE"})
 	}
-	if TailMatches(MATCH_CASE, previousWords, `\df*`) {
+	if TailMatches(MATCH_CASE, previousWords, `\df*|\sig`) {
 		return c.completeWithFunctions(text, []string{})
 	}
 	if TailMatches(MATCH_CASE, previousWords, `\di*`) {
@@ -468,6 +577,18 @@ func (c completer) complete(previousWords []string, text []rune) [][]rune {
 	if TailMatches(MATCH_CASE, previousWords, `\dt*`) {
 		return c.completeWithTables(text, []string{"TABLE", "BASE TABLE", "SYSTEM TABLE", "SYNONYM", "LOCAL TEMPORARY", "GLOBAL TEMPORARY"})
 	}
+	if TailMatches(MATCH_CASE, previousWords, `\dT*`) {
+		return c.completeWithTypes(text, []string{})
+	}
+	if TailMatches(MATCH_CASE, previousWords, `\dD*`) {
+		return c.completeWithTypes(text, []string{"DOMAIN"})
+	}
+	if TailMatches(MATCH_CASE, previousWords, `\dE*`) {
+		return c.completeWithTables(text, []string{"FOREIGN TABLE", "FOREIGN"})
+	}
+	if TailMatches(MATCH_CASE, previousWords, `\dx*`) {
+		return c.completeWithExtensions(text)
+	}
 	if TailMatches(MATCH_CASE, previousWords, `\dv*`) {
 		return c.completeWithTables(text, []string{"VIEW", "SYSTEM VIEW"})
 	}
@@ -598,6 +719,20 @@ func getPreviousWords(point int, buf []rune) []string {
 	return previousWords
 }
 
+// isCastTarget when the last word is the AS of an unclosed CAST( expression
+func isCastTarget(previousWords []string) bool {
+	if len(previousWords) < 3 || !strings.EqualFold(previousWords[0], "AS") {
+		return false
+	}
+	for i := 1; i < len(previousWords)-1; i++ {
+		w := previousWords[i]
+		if strings.HasPrefix(w, "(") && !strings.HasSuffix(w, ")") {
+			return strings.EqualFold(previousWords[i+1], "CAST")
+		}
+	}
+	return false
+}
+
 // TailMatches when last words match all patterns
 func TailMatches(ct caseType, words []string, patterns ...string) bool {
 	if len(words) < len(patterns) {
@@ -699,6 +834,14 @@ func completeFromVariables(text []rune, prefix, suffix string, needValue bool) [
 }
 
 func (c completer) completeWithSelectables(text []rune) [][]rune {
+	names := c.getSelectables(text)
+	// TODO make sure CompleteFromList would properly handle quoted identifiers
+	return CompleteFromList(text, names...)
+}
+
+// getSelectables returns sorted names of namespaces, tables, functions and
+// sequences matching text
+func (c completer) getSelectables(text []rune) []string {
 	filter := parseIdentifier(string(text))
 	names := c.getNamespaces(filter)
 	if r, ok := c.reader.(metadata.TableReader); ok {
@@ -738,8 +881,7 @@ func (c completer) completeWithSelectables(text []rune) [][]rune {
 		names = append(names, sequences...)
 	}
 	sort.Strings(names)
-	// TODO make sure CompleteFromList would properly handle quoted identifiers
-	return CompleteFromList(text, names...)
+	return names
 }
 
 func (c completer) completeWithTables(text []rune, types []string) [][]rune {
@@ -787,6 +929,67 @@ func (c completer) completeWithFunctions(text []rune, types []string) [][]rune {
 	return CompleteFromList(text, names...)
 }
 
+func (c completer) completeWithTypes(text []rune, types []string) [][]rune {
+	r, ok := c.reader.(metadata.TypeReader)
+	if !ok {
+		return [][]rune{}
+	}
+	filter := parseIdentifier(string(text))
+	filter.Types = types
+	names := c.getNamespaces(filter)
+	dataTypes := c.getNames(
+		func() (iterator, error) {
+			return r.Types(filter)
+		},
+		func(res interface{}) string {
+			t := res.(*metadata.TypeSet).Get()
+			return qualifiedIdentifier(filter, t.Catalog, t.Schema, t.Name)
+		},
+	)
+	names = append(names, dataTypes...)
+	sort.Strings(names)
+	return CompleteFromList(text, names...)
+}
+
+// completeWithDataTypes completes type names in expressions, including built-in types
+func (c completer) completeWithDataTypes(text []rune) [][]rune {
+	r, ok := c.reader.(metadata.TypeReader)
+	if !ok {
+		return nil
+	}
+	filter := parseIdentifier(string(text))
+	// built-in types usually belong to a system schema
+	filter.WithSystem = true
+	names := c.getNames(
+		func() (iterator, error) {
+			return r.Types(filter)
+		},
+		func(res interface{}) string {
+			t := res.(*metadata.TypeSet).Get()
+			return qualifiedIdentifier(filter, t.Catalog, t.Schema, t.Name)
+		},
+	)
+	sort.Strings(names)
+	return CompleteFromList(text, names...)
+}
+
+func (c completer) completeWithExtensions(text []rune) [][]rune {
+	r, ok := c.reader.(metadata.ExtensionReader)
+	if !ok {
+		return [][]rune{}
+	}
+	names := c.getNames(
+		func() (iterator, error) {
+			return r.Extensions(metadata.Filter{Name: string(text) + "%"})
+		},
+		func(res interface{}) string {
+			return res.(*metadata.ExtensionSet).Get().Name
+		},
+	)
+	sort.Strings(names)
+	return CompleteFromList(text, names...)
+}
+
 func (c completer) completeWithIndexes(text []rune) [][]rune {
 	r, ok := c.reader.(metadata.IndexReader)
 	if !ok {
@@ -941,24 +1144,92 @@ func (c completer) completeWithAttributes(ct caseType, selectable string, text [
 		)
 		names = append(names, columns...)
 	}
-	if r, ok := c.reader.(metadata.FunctionReader); ok {
-		filter := parseIdentifier(string(text))
-		// functions don't have to be fully qualified to be callable
-		filter.OnlyVisible = false
-		functions := c.getNames(
-			func() (iterator, error) {
-				return r.Functions(filter)
-			},
-			func(res interface{}) string {
-				return res.(*metadata.FunctionSet).Get().Name
-			},
-		)
-		names = append(names, functions...)
+	names = append(names, c.getFunctionNames(text)...)
+	names = append(names, options...)
+	return CompleteFromList(text, names...)
+}
+
+func (c completer) completeWithColumns(text []rune, refs []tableRef, options ...string) [][]rune {
+	names := make([]string, 0, 10)
+	seen := make(map[string]struct{})
+	for _, ref := range refs {
+		for _, name := range c.getColumns(ref) {
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+		}
 	}
+	names = append(names, c.getFunctionNames(text)...)
 	names = append(names, options...)
 	return CompleteFromList(text, names...)
 }
 
+// getColumns of a table referenced by a statement
+func (c completer) getColumns(ref tableRef) []string {
+	if ref.derived {
+		return append([]string{}, ref.columns...)
+	}
+	r, ok := c.reader.(metadata.ColumnReader)
+	if !ok {
+		return nil
+	}
+	filter := metadata.Filter{
+		Catalog:     ref.catalog,
+		Schema:      ref.schema,
+		Parent:      ref.name,
+		OnlyVisible: ref.schema == "",
+		WithSystem:  ref.schema != "",
+	}
+	return c.getNames(
+		func() (iterator, error) {
+			return r.Columns(filter)
+		},
+		func(res interface{}) string {
+			return res.(*metadata.ColumnSet).Get().Name
+		},
+	)
+}
+
+// getFunctionNames matching text, that can be used in expressions
+func (c completer) getFunctionNames(text []rune) []string {
+	var names []string
+	// built-in functions are never qualified
+	if !strings.ContainsRune(string(text), '.') {
+		names = append(names, c.functions...)
+	}
+	r, ok := c.reader.(metadata.FunctionReader)
+	if !ok {
+		return names
+	}
+	filter := parseIdentifier(string(text))
+	// functions don't have to be fully qualified to be callable
+	filter.OnlyVisible = false
+	return append(c.getNames(
+		func() (iterator, error) {
+			return r.Functions(filter)
+		},
+		func(res interface{}) string {
+			return res.(*metadata.FunctionSet).Get().Name
+		},
+	), names...)
+}
+
+// withoutCompletions returns completions not in excluded
+func withoutCompletions(completions, excluded [][]rune) [][]rune {
+	seen := make(map[string]bool, len(excluded))
+	for _, e := range excluded {
+		seen[string(e)] = true
+	}
+	result := make([][]rune, 0, len(completions))
+	for _, c := range completions {
+		if !seen[string(c)] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
 // parseIdentifier into catalog, schema and name
 func parseIdentifier(name string) metadata.Filter {
 	// TODO handle quoted identifiers
@@ -1031,13 +1302,14 @@ func (c completer) getNames(query func() (iterator, error), mapper func(interfac
 	defer res.Close()
 
 	// there can be duplicates if names are not qualified
-	values := make(map[string]struct{}, 10)
+	seen := make(map[string]struct{}, 10)
+	result := make([]string, 0, 10)
 	for res.Next() {
-		values[mapper(res)] = struct{}{}
-	}
-	result := make([]string, 0, len(values))
-	for v := range values {
-		result = append(result, v)
+		v := mapper(res)
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
 	}
 	return result
 }
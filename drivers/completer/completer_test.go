@@ -1,6 +1,8 @@
 package completer
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/xo/usql/drivers/metadata"
@@ -287,6 +289,163 @@ func TestCompleter(t *testing.T) {
 			},
 			16,
 		},
+		{
+			"usql data types command",
+			`\dT`,
+			3,
+			[]string{
+				`+`,
+				``,
+				`S+`,
+				`S`,
+			},
+			3,
+		},
+		{
+			"data types",
+			`\dT mo`,
+			6,
+			[]string{
+				"od",
+			},
+			2,
+		},
+		{
+			"domains",
+			`\dD `,
+			4,
+			[]string{
+				"main",
+				"remote",
+				"default",
+				"system",
+				"posint",
+			},
+			0,
+		},
+		{
+			"type cast",
+			"SELECT 'x'::m",
+			13,
+			[]string{
+				"ood",
+				"oney",
+			},
+			6,
+		},
+		{
+			"cast as",
+			"SELECT CAST(a + b AS ",
+			21,
+			[]string{
+				"mood",
+				"money",
+				"posint",
+			},
+			0,
+		},
+		{
+			"alias columns",
+			"SELECT f. FROM film f",
+			9,
+			[]string{
+				"id",
+				"name",
+			},
+			2,
+		},
+		{
+			"join alias columns",
+			"SELECT * FROM film f JOIN factory AS fa ON fa.id = f.",
+			53,
+			[]string{
+				"id",
+				"name",
+			},
+			2,
+		},
+		{
+			"quoted alias columns",
+			`SELECT "F".na FROM film AS "F"`,
+			13,
+			[]string{
+				"me",
+			},
+			6,
+		},
+		{
+			"schema qualified table columns",
+			"SELECT * FROM system.film WHERE film.i",
+			38,
+			[]string{
+				"d",
+			},
+			6,
+		},
+		{
+			"select list columns",
+			"SELECT  FROM film",
+			7,
+			[]string{
+				"id",
+				"name",
+				"CASE",
+				"AND",
+				"OR",
+				"WHEN",
+				"THEN",
+				"ELSE",
+				"END",
+			},
+			0,
+		},
+		{
+			"order by columns",
+			"SELECT * FROM film f ORDER BY n",
+			31,
+			[]string{
+				"ame",
+			},
+			1,
+		},
+		{
+			"cte columns",
+			"WITH x AS (SELECT id, name AS title FROM film) SELECT x. FROM x",
+			56,
+			[]string{
+				"id",
+				"title",
+			},
+			2,
+		},
+		{
+			"too many name parts",
+			"SELECT a.b.c.d. FROM film",
+			15,
+			[]string{},
+			8,
+		},
+		{
+			"subquery columns",
+			"SELECT s. FROM (SELECT count(*) AS n FROM film) s",
+			9,
+			[]string{
+				"n",
+			},
+			2,
+		},
+		{
+			"join condition from column names",
+			"SELECT * FROM factory f JOIN film m ON ",
+			39,
+			[]string{
+				"f.film_id = m.id",
+				"id",
+				"name",
+				"film_id",
+			},
+			0,
+		},
 	}
 
 	completer := NewDefaultCompleter(WithReader(mockReader{}), WithConnStrings([]string{"pg://"}))
@@ -329,6 +488,7 @@ type mockReader struct{}
 
 var _ metadata.CatalogReader = &mockReader{}
 var _ metadata.BasicReader = &mockReader{}
+var _ metadata.TypeReader = &mockReader{}
 
 func (r mockReader) Catalogs(metadata.Filter) (*metadata.CatalogSet, error) {
 	return metadata.NewCatalogSet([]metadata.Catalog{
@@ -380,6 +540,16 @@ func (r mockReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
 			},
 		}), nil
 	}
+	if f.Parent == "factory" {
+		return metadata.NewColumnSet([]metadata.Column{
+			{
+				Name: "id",
+			},
+			{
+				Name: "film_id",
+			},
+		}), nil
+	}
 	return metadata.NewColumnSet([]metadata.Column{
 		{
 			Name: f.Catalog,
@@ -392,3 +562,514 @@ func (r mockReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
 		},
 	}), nil
 }
+
+func (r mockReader) Types(f metadata.Filter) (*metadata.TypeSet, error) {
+	types := []metadata.Type{
+		{
+			Name: "mood",
+			Kind: "ENUM",
+		},
+		{
+			Name: "posint",
+			Kind: "DOMAIN",
+		},
+	}
+	if f.WithSystem {
+		types = append(types, metadata.Type{
+			Name: "money",
+			Kind: "BASE",
+		})
+	}
+	result := []metadata.Type{}
+	for _, t := range types {
+		if len(f.Types) != 0 && f.Types[0] != t.Kind {
+			continue
+		}
+		result = append(result, t)
+	}
+	return metadata.NewTypeSet(result), nil
+}
+
+func TestReaderBeforeComplete(t *testing.T) {
+	var reader metadata.Reader
+	completer := NewDefaultCompleter(
+		WithReaderBeforeComplete(func(r metadata.Reader) CompleteFunc {
+			reader = r
+			return func([]string, []rune) [][]rune {
+				return [][]rune{[]rune("db")}
+			}
+		}),
+		WithReader(mockReader{}),
+	)
+	if _, ok := reader.(mockReader); !ok {
+		t.Errorf("Expected before complete func to use the completer reader, got %T", reader)
+	}
+	if suggestions, _ := completer.Do([]rune("USE "), 4); len(suggestions) != 1 || string(suggestions[0]) != "db" {
+		t.Errorf("Expected suggestions of before complete func, got %v", suggestions)
+	}
+}
+
+func TestJoinCompleter(t *testing.T) {
+	cases := []struct {
+		name           string
+		line           string
+		expSuggestions []string
+	}{
+		{
+			"related tables first",
+			"SELECT * FROM orders o JOIN ",
+			[]string{
+				"customers",
+				"orders",
+				"products",
+			},
+		},
+		{
+			"referencing tables first",
+			"SELECT * FROM customers c JOIN ",
+			[]string{
+				"orders",
+				"customers",
+				"products",
+			},
+		},
+		{
+			"join condition",
+			"SELECT * FROM orders o JOIN customers AS c ON ",
+			[]string{
+				"o.customer_id = c.id",
+				"id",
+				"customer_id",
+				"name",
+			},
+		},
+	}
+	completer := NewDefaultCompleter(WithReader(fkReader{}))
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			suggestions, _ := completer.Do([]rune(test.line), len(test.line))
+			actual := make([]string, len(suggestions))
+			for i, s := range suggestions {
+				actual[i] = string(s)
+			}
+			if strings.Join(actual, ",") != strings.Join(test.expSuggestions, ",") {
+				t.Errorf("Expected suggestions %v, got %v", test.expSuggestions, actual)
+			}
+		})
+	}
+}
+
+type fkReader struct{}
+
+func (r fkReader) Tables(f metadata.Filter) (*metadata.TableSet, error) {
+	return metadata.NewTableSet([]metadata.Table{
+		{Name: "customers"},
+		{Name: "orders"},
+		{Name: "products"},
+	}), nil
+}
+
+func (r fkReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
+	columns := map[string][]metadata.Column{
+		"customers": {{Name: "id"}, {Name: "name"}},
+		"orders":    {{Name: "id"}, {Name: "customer_id"}},
+	}
+	return metadata.NewColumnSet(columns[f.Parent]), nil
+}
+
+func (r fkReader) Constraints(f metadata.Filter) (*metadata.ConstraintSet, error) {
+	fk := metadata.Constraint{
+		Table:        "orders",
+		Name:         "orders_customer_id_fkey",
+		Type:         "FOREIGN KEY",
+		ForeignTable: "customers",
+	}
+	if f.Parent == fk.Table || f.Reference == fk.ForeignTable {
+		return metadata.NewConstraintSet([]metadata.Constraint{fk}), nil
+	}
+	return metadata.NewConstraintSet([]metadata.Constraint{}), nil
+}
+
+func (r fkReader) ConstraintColumns(f metadata.Filter) (*metadata.ConstraintColumnSet, error) {
+	return metadata.NewConstraintColumnSet([]metadata.ConstraintColumn{
+		{Table: "orders", Constraint: f.Name, Name: "customer_id", ForeignTable: "customers", ForeignName: "id"},
+	}), nil
+}
+
+func TestFunctionCompleter(t *testing.T) {
+	cases := []struct {
+		name           string
+		line           string
+		expSuggestions []string
+		expHints       string
+	}{
+		{
+			"enum labels",
+			"SELECT set_mood(",
+			[]string{
+				"'happy'",
+				"'sad'",
+			},
+			"set_mood(m public.mood) RETURNS void\n",
+		},
+		{
+			"columns of argument type",
+			"SELECT * FROM film WHERE rank(1, ",
+			[]string{
+				"length",
+			},
+			"rank(a integer, b integer, OUT r integer) RETURNS integer\n",
+		},
+		{
+			"partial argument",
+			"SELECT * FROM film WHERE rank(1, le",
+			[]string{
+				"ngth",
+			},
+			"",
+		},
+		{
+			"procedure",
+			"EXEC set_mood ",
+			[]string{
+				"'happy'",
+				"'sad'",
+			},
+			"set_mood(m public.mood) RETURNS void\n",
+		},
+		{
+			"unknown function",
+			"SELECT * FROM film WHERE nope(",
+			[]string{
+				"length",
+				"title",
+				"rank",
+				"set_mood",
+				"AND",
+				"OR",
+				"CASE",
+				"WHEN",
+				"THEN",
+				"ELSE",
+				"END",
+			},
+			"",
+		},
+		{
+			"not a function",
+			"INSERT INTO film (",
+			[]string{
+				"length",
+				"title",
+				"rank",
+				"set_mood",
+			},
+			"",
+		},
+		{
+			"sig",
+			`\sig r`,
+			[]string{
+				"ank",
+			},
+			"",
+		},
+	}
+	var hints bytes.Buffer
+	completer := NewDefaultCompleter(WithReader(funcReader{}), WithHints(&hints))
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			hints.Reset()
+			suggestions, _ := completer.Do([]rune(test.line), len(test.line))
+			actual := make([]string, len(suggestions))
+			for i, s := range suggestions {
+				actual[i] = string(s)
+				if strings.HasPrefix(actual[i], "/*") {
+					t.Errorf("Unexpected signature suggestion: %s", actual[i])
+				}
+			}
+			if strings.Join(actual, ",") != strings.Join(test.expSuggestions, ",") {
+				t.Errorf("Expected suggestions %v, got %v", test.expSuggestions, actual)
+			}
+			if s := hints.String(); s != test.expHints {
+				t.Errorf("Expected hints %q, got %q", test.expHints, s)
+			}
+		})
+	}
+}
+
+type funcReader struct{}
+
+func (r funcReader) Tables(f metadata.Filter) (*metadata.TableSet, error) {
+	return metadata.NewTableSet([]metadata.Table{{Name: "film"}}), nil
+}
+
+func (r funcReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
+	return metadata.NewColumnSet([]metadata.Column{
+		{Table: "film", Name: "length", DataType: "integer"},
+		{Table: "film", Name: "title", DataType: "character varying(255)"},
+	}), nil
+}
+
+func (r funcReader) Types(f metadata.Filter) (*metadata.TypeSet, error) {
+	if f.Name != "mood" {
+		return metadata.NewTypeSet([]metadata.Type{}), nil
+	}
+	return metadata.NewTypeSet([]metadata.Type{
+		{Schema: "public", Name: "mood", Kind: "ENUM", Labels: []string{"happy", "sad"}},
+	}), nil
+}
+
+func (r funcReader) Functions(f metadata.Filter) (*metadata.FunctionSet, error) {
+	functions := []metadata.Function{
+		{Schema: "public", Name: "rank", ResultType: "integer", Type: "FUNCTION", SpecificName: "rank_1"},
+		{Schema: "public", Name: "set_mood", ResultType: "void", Type: "PROCEDURE", SpecificName: "set_mood_1"},
+	}
+	result := []metadata.Function{}
+	for _, fn := range functions {
+		if strings.HasPrefix(fn.Name, strings.TrimSuffix(f.Name, "%")) {
+			result = append(result, fn)
+		}
+	}
+	return metadata.NewFunctionSet(result), nil
+}
+
+func (r funcReader) FunctionColumns(f metadata.Filter) (*metadata.FunctionColumnSet, error) {
+	columns := map[string][]metadata.FunctionColumn{
+		"rank_1": {
+			{Name: "a", OrdinalPosition: 1, Type: "IN", DataType: "integer"},
+			{Name: "b", OrdinalPosition: 2, Type: "IN", DataType: "integer"},
+			{Name: "r", OrdinalPosition: 3, Type: "OUT", DataType: "integer"},
+		},
+		"set_mood_1": {
+			{Name: "m", OrdinalPosition: 1, Type: "IN", DataType: "public.mood"},
+		},
+	}
+	return metadata.NewFunctionColumnSet(columns[f.Parent]), nil
+}
+
+func TestDialectCompleter(t *testing.T) {
+	dialect := LexerDialect("mysql").Merge(Dialect{
+		StartCommands: []string{"USE"},
+		Functions:     []string{"IFNULL"},
+		Clauses: []Clause{
+			{Statement: "INSERT", After: []string{"*)"}, Keywords: []string{"ON DUPLICATE KEY UPDATE"}},
+			{Statement: "SELECT", After: []string{"LIMIT", "*"}, Keywords: []string{"OFFSET"}},
+		},
+	})
+	cases := []struct {
+		name   string
+		line   string
+		start  int
+		exp    []string
+		absent []string
+	}{
+		{
+			"dialect start command",
+			"US",
+			2,
+			[]string{"E"},
+			nil,
+		},
+		{
+			"no start command of other dialects",
+			"VAC",
+			3,
+			nil,
+			[]string{"UUM"},
+		},
+		{
+			"no command of other dialects",
+			"SELECT * FROM film ",
+			19,
+			[]string{"JOIN", "LEFT JOIN"},
+			[]string{"FULL OUTER JOIN"},
+		},
+		{
+			"clause",
+			"INSERT INTO film VALUES (1, 'a') ",
+			33,
+			[]string{"ON DUPLICATE KEY UPDATE"},
+			nil,
+		},
+		{
+			"clause of other statements",
+			"DELETE FROM film WHERE id IN (1) ",
+			33,
+			nil,
+			[]string{"ON DUPLICATE KEY UPDATE"},
+		},
+		{
+			"clause after words",
+			"SELECT * FROM film LIMIT 10 OF",
+			30,
+			[]string{"FSET"},
+			nil,
+		},
+		{
+			"built-in functions",
+			"SELECT IFN FROM film",
+			10,
+			[]string{"ULL"},
+			nil,
+		},
+	}
+	completer := NewDefaultCompleter(WithReader(mockReader{}), WithDialect(dialect))
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			suggestions, _ := completer.Do([]rune(test.line), test.start)
+			actual := make(map[string]bool, len(suggestions))
+			for _, s := range suggestions {
+				actual[string(s)] = true
+			}
+			for _, exp := range test.exp {
+				if !actual[exp] {
+					t.Errorf("Missing expected suggestion: %s", exp)
+				}
+			}
+			for _, s := range test.absent {
+				if actual[s] {
+					t.Errorf("Unexpected suggestion: %s", s)
+				}
+			}
+		})
+	}
+}
+
+func TestLexerDialect(t *testing.T) {
+	d := LexerDialect("tsql")
+	for _, exp := range []string{"SELECT", "MERGE"} {
+		if findColumn(d.Keywords, exp) == "" {
+			t.Errorf("Missing expected keyword: %s", exp)
+		}
+	}
+	if findColumn(d.Functions, "DATEADD") == "" {
+		t.Errorf("Missing expected function: DATEADD")
+	}
+	if d := LexerDialect("unknown"); len(d.Keywords) != 0 || len(d.Functions) != 0 {
+		t.Errorf("Expected empty dialect, got %v", d)
+	}
+}
+
+func TestBackslashArgsCompleter(t *testing.T) {
+	cases := []struct {
+		name           string
+		line           string
+		expSuggestions []string
+	}{
+		{
+			"isolation level",
+			`\begin read-`,
+			[]string{
+				"committed",
+				"uncommitted",
+			},
+		},
+		{
+			"isolation level after flag",
+			`\begin -read-only s`,
+			[]string{
+				"erializable",
+				"napshot",
+			},
+		},
+		{
+			"duration",
+			`\watch 1`,
+			[]string{
+				"s",
+				"0s",
+				"m",
+			},
+		},
+		{
+			"format option names",
+			`\g (tuples`,
+			[]string{
+				"_only",
+			},
+		},
+		{
+			"format option values",
+			`\g (format=c`,
+			[]string{
+				"sv",
+			},
+		},
+		{
+			"format option values after other options",
+			`\g (tuples_only=on expanded=`,
+			[]string{
+				"auto",
+				"on",
+				"off",
+			},
+		},
+		{
+			"table from destination connection",
+			`\copy pg://a my://b 'select 1, 2' c`,
+			[]string{
+				"ustomers",
+			},
+		},
+		{
+			"columns of destination table",
+			`\copy pg://a my://b 'select 1, 2' customers(id,n`,
+			[]string{
+				"ame",
+			},
+		},
+		{
+			"columns of query",
+			`SELECT * FROM film \crosstabview n`,
+			[]string{
+				"ame",
+			},
+		},
+		{
+			"connection",
+			`\c p`,
+			[]string{
+				"g://",
+			},
+		},
+		{
+			"too many arguments",
+			`\unset a b`,
+			nil,
+		},
+	}
+	kinds := map[string][]ArgKind{
+		"begin":        {ArgIsolationLevel},
+		"c":            {ArgConnection},
+		"copy":         {ArgConnection, ArgConnection, ArgNone, ArgTable},
+		"crosstabview": {ArgFormatOption, ArgColumns},
+		"g":            {ArgFormatOption, ArgFile},
+		"unset":        {ArgVariable},
+		"watch":        {ArgFormatOption, ArgDuration},
+	}
+	connReader := func(dsn string) metadata.Reader {
+		if dsn == "my://b" {
+			return fkReader{}
+		}
+		return nil
+	}
+	completer := NewDefaultCompleter(
+		WithReader(mockReader{}),
+		WithConnStrings([]string{"pg://"}),
+		WithArgKinds(kinds),
+		WithConnReader(connReader),
+	)
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			suggestions, _ := completer.Do([]rune(test.line), len(test.line))
+			actual := make([]string, len(suggestions))
+			for i, s := range suggestions {
+				actual[i] = string(s)
+			}
+			if strings.Join(actual, ",") != strings.Join(test.expSuggestions, ",") {
+				t.Errorf("Expected suggestions %v, got %v", test.expSuggestions, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package completer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+// Dialect of SQL completed for a database.
+type Dialect struct {
+	// Keywords of the dialect; when not empty, common commands are only
+	// completed when all their words are keywords.
+	Keywords []string
+	// StartCommands that can begin a query, in addition to common ones.
+	StartCommands []string
+	// Commands that can be any part of a query, in addition to common ones.
+	Commands []string
+	// Functions are built-in functions, completed along with columns.
+	Functions []string
+	// Clauses are hints for dialect specific clauses.
+	Clauses []Clause
+}
+
+// Clause completes Keywords in statements starting with Statement, when
+// previous words match After.
+type Clause struct {
+	// Statement is a pattern matching the first word of the statement, or
+	// empty to match any statement.
+	Statement string
+	// After are patterns matching previous words, as in TailMatches.
+	After []string
+	// Keywords completed after matching words.
+	Keywords []string
+}
+
+// Merge returns the dialect with keywords, commands, functions and clauses
+// of other added.
+func (d Dialect) Merge(other Dialect) Dialect {
+	return Dialect{
+		Keywords:      append(append([]string{}, d.Keywords...), other.Keywords...),
+		StartCommands: append(append([]string{}, d.StartCommands...), other.StartCommands...),
+		Commands:      append(append([]string{}, d.Commands...), other.Commands...),
+		Functions:     append(append([]string{}, d.Functions...), other.Functions...),
+		Clauses:       append(append([]Clause{}, d.Clauses...), other.Clauses...),
+	}
+}
+
+// lexerWordsRE matches patterns of lexer rules listing alternatives.
+var lexerWordsRE = regexp.MustCompile(`^(?:\\b)?\((?:\?:)?(.+)\)(?:\\b)?$`)
+
+// wordRE matches words.
+var wordRE = regexp.MustCompile(`^\w+$`)
+
+// LexerDialect returns a dialect with keywords and functions of the named
+// syntax lexer, or an empty dialect if there is no such lexer.
+func LexerDialect(name string) Dialect {
+	var d Dialect
+	l, ok := lexers.Get(name).(*chroma.RegexLexer)
+	if name == "" || !ok {
+		return d
+	}
+	rules, err := l.Rules()
+	if err != nil {
+		return d
+	}
+	for _, state := range rules {
+		for _, rule := range state {
+			typ, ok := rule.Type.(chroma.TokenType)
+			if !ok {
+				continue
+			}
+			m := lexerWordsRE.FindStringSubmatch(rule.Pattern)
+			if m == nil {
+				continue
+			}
+			var words []string
+			for _, word := range strings.Split(strings.ToUpper(m[1]), "|") {
+				if wordRE.MatchString(word) {
+					words = append(words, word)
+				}
+			}
+			switch typ {
+			case chroma.Keyword, chroma.KeywordReserved, chroma.KeywordConstant, chroma.NameConstant:
+				d.Keywords = append(d.Keywords, words...)
+			case chroma.NameFunction:
+				d.Functions = append(d.Functions, words...)
+			}
+		}
+	}
+	return d
+}
+
+// WithDialect option, replacing common commands with commands of the dialect.
+func WithDialect(d Dialect) Option {
+	return func(c *completer) {
+		c.sqlStartCommands = dialectCommands(CommonSqlStartCommands, d.Keywords, d.StartCommands)
+		c.sqlCommands = dialectCommands(CommonSqlCommands, d.Keywords, d.Commands)
+		c.functions = d.Functions
+		c.clauses = d.Clauses
+	}
+}
+
+// dialectCommands returns sorted common commands consisting of keywords,
+// and additional commands.
+func dialectCommands(common, keywords, additional []string) []string {
+	known := make(map[string]bool, len(keywords))
+	for _, k := range keywords {
+		known[strings.ToUpper(k)] = true
+	}
+	seen := make(map[string]bool)
+	var commands []string
+	add := func(cmd string) {
+		if !seen[cmd] {
+			seen[cmd] = true
+			commands = append(commands, cmd)
+		}
+	}
+	for _, cmd := range common {
+		ok := true
+		for _, word := range strings.Fields(cmd) {
+			ok = ok && (len(keywords) == 0 || known[word])
+		}
+		if ok {
+			add(cmd)
+		}
+	}
+	for _, cmd := range additional {
+		add(cmd)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// completeWithClauses completes keywords of dialect clauses matching
+// previous words, in a statement starting with keyword.
+func (c completer) completeWithClauses(previousWords []string, text []rune, keyword string) [][]rune {
+	var keywords []string
+	for _, clause := range c.clauses {
+		if clause.Statement != "" && (keyword == "" || !wordMatches(IGNORE_CASE, clause.Statement, keyword)) {
+			continue
+		}
+		if TailMatches(IGNORE_CASE, previousWords, clause.After...) {
+			keywords = append(keywords, clause.Keywords...)
+		}
+	}
+	return CompleteFromList(text, keywords...)
+}
+
+// statementKeyword returns the first word of the statement ending the line.
+func statementKeyword(line []rune) string {
+	tokens := currentStatement(line, len(line))
+	if len(tokens) == 0 || tokens[0].kind != tokIdent || tokens[0].quoted {
+		return ""
+	}
+	return tokens[0].text
+}
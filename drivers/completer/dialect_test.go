@@ -0,0 +1,40 @@
+package completer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xo/dburl"
+	"github.com/xo/usql/drivers"
+	_ "github.com/xo/usql/drivers/clickhouse"
+)
+
+func TestDriverDialect(t *testing.T) {
+	tests := []struct {
+		driver string
+		line   string
+		exp    []string
+		absent []string
+	}{
+		{"clickhouse", "", []string{"OPTIMIZE TABLE", "SELECT", "SYSTEM"}, []string{"VACUUM", "LISTEN", "NOTIFY", "REINDEX", "CLUSTER", "DISCARD"}},
+		{"clickhouse", "SELECT * FROM t ", []string{"WHERE", "PREWHERE"}, []string{"FETCH"}},
+	}
+	for i, test := range tests {
+		c := drivers.NewCompleter(context.Background(), &dburl.URL{Driver: test.driver}, nil, nil)
+		suggestions, n := c.Do([]rune(test.line), len(test.line))
+		actual := make(map[string]bool, len(suggestions))
+		for _, s := range suggestions {
+			actual[test.line[len(test.line)-n:]+string(s)] = true
+		}
+		for _, exp := range test.exp {
+			if !actual[exp] {
+				t.Errorf("test %d expected suggestion %q, got: %v", i, exp, actual)
+			}
+		}
+		for _, s := range test.absent {
+			if actual[s] {
+				t.Errorf("test %d expected no suggestion %q", i, s)
+			}
+		}
+	}
+}
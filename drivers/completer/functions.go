@@ -0,0 +1,265 @@
+package completer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/text"
+)
+
+// funcCall is a function or procedure call containing the completed word
+type funcCall struct {
+	schema string
+	name   string
+	// arg is the position of the completed argument, starting at 0
+	arg int
+}
+
+// notCallable are keywords that can precede parentheses, but are not functions
+var notCallable = []string{
+	"ALL", "AND", "ANY", "AS", "CAST", "EXISTS", "FILTER", "FROM", "IN", "JOIN",
+	"NOT", "ON", "OR", "OVER", "RETURNS", "SELECT", "SOME", "TABLE", "USING",
+	"VALUES", "WHERE", "WITHIN",
+}
+
+// declKeywords are keywords preceding names that are followed by column or
+// parameter lists, instead of arguments
+var declKeywords = []string{
+	"AS", "FUNCTION", "INDEX", "INTO", "ON", "PROCEDURE", "REFERENCES", "TABLE",
+	"UPDATE", "VIEW", "WITH",
+}
+
+// findFunctionCall returns the function call containing the end of line, if
+// any, either as a name followed by parentheses, or as an EXEC statement
+func findFunctionCall(line []rune) *funcCall {
+	tokens := currentStatement(line, len(line))
+	type paren struct{ pos, arg int }
+	var parens []paren
+	for i, t := range tokens {
+		switch {
+		case t.isPunct("("):
+			parens = append(parens, paren{pos: i})
+		case t.isPunct(")") && len(parens) != 0:
+			parens = parens[:len(parens)-1]
+		case t.isPunct(",") && len(parens) != 0:
+			parens[len(parens)-1].arg++
+		}
+	}
+	if len(parens) != 0 {
+		p := parens[len(parens)-1]
+		i := p.pos - 1
+		if i < 0 || tokens[i].kind != tokIdent || tokens[i].is(notCallable...) {
+			return nil
+		}
+		call := &funcCall{name: tokens[i].text, arg: p.arg}
+		if i > 1 && tokens[i-1].isPunct(".") && tokens[i-2].kind == tokIdent {
+			call.schema = tokens[i-2].text
+			i -= 2
+		}
+		if i > 0 && tokens[i-1].is(declKeywords...) {
+			return nil
+		}
+		return call
+	}
+	// EXEC proc arg, ...
+	if len(tokens) < 2 || !tokens[0].is("EXEC", "EXECUTE") || tokens[1].kind != tokIdent {
+		return nil
+	}
+	call, i := &funcCall{name: tokens[1].text}, 2
+	if i+1 < len(tokens) && tokens[i].isPunct(".") && tokens[i+1].kind == tokIdent {
+		call.schema, call.name = call.name, tokens[i+1].text
+		i += 2
+	}
+	// still completing the procedure name
+	if i == len(tokens) && !unicode.IsSpace(line[len(line)-1]) {
+		return nil
+	}
+	for ; i < len(tokens); i++ {
+		if tokens[i].isPunct(",") {
+			call.arg++
+		}
+	}
+	return call
+}
+
+// signature of a function
+type signature struct {
+	metadata.Function
+	args []metadata.FunctionColumn
+}
+
+// inputs returns input arguments of the function
+func (s signature) inputs() []metadata.FunctionColumn {
+	var args []metadata.FunctionColumn
+	for _, arg := range s.args {
+		// skip result params
+		if arg.OrdinalPosition != 0 && arg.Type != "OUT" {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+func (s signature) String() string {
+	args := make([]string, 0, len(s.args))
+	for _, arg := range s.args {
+		if arg.OrdinalPosition == 0 {
+			continue
+		}
+		var parts []string
+		if arg.Type != "IN" && arg.Type != "" {
+			parts = append(parts, arg.Type)
+		}
+		if arg.Name != "" {
+			parts = append(parts, arg.Name)
+		}
+		args = append(args, strings.Join(append(parts, arg.DataType), " "))
+	}
+	sig := fmt.Sprintf("%s(%s)", s.Name, strings.Join(args, ", "))
+	if s.ResultType != "" {
+		sig += " RETURNS " + s.ResultType
+	}
+	return sig
+}
+
+// getSignatures returns signatures of all overloads of the called function
+func (c completer) getSignatures(call *funcCall) []signature {
+	r, ok := c.reader.(metadata.FunctionReader)
+	if !ok {
+		return nil
+	}
+	res, err := r.Functions(metadata.Filter{Schema: call.schema, Name: call.name, OnlyVisible: call.schema == "", WithSystem: true})
+	if err != nil {
+		if err != text.ErrNotSupported {
+			c.logger.Println("Error getting functions", err)
+		}
+		return nil
+	}
+	var sigs []signature
+	for res.Next() {
+		// name is a pattern, so check it exactly
+		if f := res.Get(); strings.EqualFold(f.Name, call.name) {
+			sigs = append(sigs, signature{Function: *f})
+		}
+	}
+	res.Close()
+	cr, ok := c.reader.(metadata.FunctionColumnReader)
+	if !ok {
+		return sigs
+	}
+	for i, sig := range sigs {
+		cols, err := cr.FunctionColumns(metadata.Filter{Catalog: sig.Catalog, Schema: sig.Schema, Parent: sig.SpecificName})
+		if err != nil {
+			if err != text.ErrNotSupported {
+				c.logger.Println("Error getting function columns", err)
+			}
+			continue
+		}
+		for cols.Next() {
+			sigs[i].args = append(sigs[i].args, *cols.Get())
+		}
+		cols.Close()
+	}
+	return sigs
+}
+
+// completeWithArguments completes arguments of function calls, with enum
+// labels or columns of the argument type, and writes signatures of the
+// function as hints, when there is no text yet
+func (c completer) completeWithArguments(text []rune, refs []tableRef, call *funcCall) [][]rune {
+	sigs := c.getSignatures(call)
+	if len(sigs) == 0 {
+		return nil
+	}
+	var values []string
+	seen := make(map[string]bool)
+	for _, sig := range sigs {
+		args := sig.inputs()
+		if call.arg >= len(args) {
+			continue
+		}
+		for _, v := range c.getArgumentValues(args[call.arg], refs) {
+			if !seen[v] {
+				seen[v] = true
+				values = append(values, v)
+			}
+		}
+	}
+	result := CompleteFromList(text, values...)
+	if len(values) == 0 {
+		result = c.completeWithColumns(text, refs)
+	}
+	if len(text) == 0 && c.hints != nil {
+		for _, sig := range sigs {
+			fmt.Fprintln(c.hints, sig.String())
+		}
+	}
+	return result
+}
+
+// getArgumentValues returns enum labels, or columns of referenced tables,
+// matching the type of the argument
+func (c completer) getArgumentValues(arg metadata.FunctionColumn, refs []tableRef) []string {
+	if labels := c.getEnumLabels(arg.DataType); len(labels) != 0 {
+		return labels
+	}
+	r, ok := c.reader.(metadata.ColumnReader)
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, ref := range refs {
+		if ref.derived {
+			continue
+		}
+		res, err := r.Columns(metadata.Filter{Catalog: ref.catalog, Schema: ref.schema, Parent: ref.name, OnlyVisible: ref.schema == "", WithSystem: ref.schema != ""})
+		if err != nil {
+			continue
+		}
+		for res.Next() {
+			if col := res.Get(); strings.EqualFold(col.Table, ref.name) && baseType(col.DataType) == baseType(arg.DataType) {
+				names = append(names, col.Name)
+			}
+		}
+		res.Close()
+	}
+	return names
+}
+
+// getEnumLabels returns quoted labels of an enum type
+func (c completer) getEnumLabels(dataType string) []string {
+	r, ok := c.reader.(metadata.TypeReader)
+	if !ok || dataType == "" {
+		return nil
+	}
+	filter := metadata.Filter{Name: dataType, Types: []string{"ENUM"}, WithSystem: true}
+	if i := strings.LastIndex(dataType, "."); i != -1 {
+		filter.Schema, filter.Name = dataType[:i], dataType[i+1:]
+	}
+	res, err := r.Types(filter)
+	if err != nil {
+		return nil
+	}
+	defer res.Close()
+	var labels []string
+	for res.Next() {
+		t := res.Get()
+		if t.Kind != "ENUM" || !strings.EqualFold(t.Name, filter.Name) {
+			continue
+		}
+		for _, l := range t.Labels {
+			labels = append(labels, "'"+strings.ReplaceAll(l, "'", "''")+"'")
+		}
+	}
+	return labels
+}
+
+// baseType returns the lower case data type, without size or precision
+func baseType(dataType string) string {
+	if i := strings.IndexRune(dataType, '('); i != -1 {
+		dataType = dataType[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(dataType))
+}
@@ -0,0 +1,272 @@
+package completer
+
+import (
+	"strings"
+
+	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/text"
+)
+
+// foreignKey from columns of a table to columns of a foreign table
+type foreignKey struct {
+	schema         string
+	table          string
+	columns        []string
+	foreignSchema  string
+	foreignTable   string
+	foreignColumns []string
+}
+
+// getForeignKeys of a referenced table, both from and to the table; returns
+// false if the reader cannot read foreign keys
+func (c completer) getForeignKeys(ref tableRef) ([]foreignKey, bool) {
+	fks, err := c.readForeignKeys(ref)
+	switch {
+	case err == text.ErrNotSupported:
+		return nil, false
+	case err != nil:
+		c.logger.Println("Error getting foreign keys", err)
+	}
+	return fks, true
+}
+
+func (c completer) readForeignKeys(ref tableRef) ([]foreignKey, error) {
+	r, ok := c.reader.(metadata.ConstraintReader)
+	if !ok || ref.derived {
+		return nil, text.ErrNotSupported
+	}
+	var fks []foreignKey
+	filters := []metadata.Filter{
+		{Catalog: ref.catalog, Schema: ref.schema, Parent: ref.name},
+		{Catalog: ref.catalog, Schema: ref.schema, Reference: ref.name},
+	}
+	for i, f := range filters {
+		res, err := r.Constraints(f)
+		if err != nil {
+			return nil, err
+		}
+		var constraints []metadata.Constraint
+		for res.Next() {
+			con := res.Get()
+			// filters are patterns, so check names exactly
+			if con.Type != "FOREIGN KEY" ||
+				(i == 0 && !strings.EqualFold(con.Table, ref.name)) ||
+				(i == 1 && !strings.EqualFold(con.ForeignTable, ref.name)) {
+				continue
+			}
+			constraints = append(constraints, *con)
+		}
+		res.Close()
+		for _, con := range constraints {
+			fk := foreignKey{
+				schema:        con.Schema,
+				table:         con.Table,
+				foreignSchema: con.ForeignSchema,
+				foreignTable:  con.ForeignTable,
+			}
+			fk.columns, fk.foreignColumns, err = c.getConstraintColumns(con)
+			if err != nil {
+				return nil, err
+			}
+			fks = append(fks, fk)
+		}
+	}
+	return fks, nil
+}
+
+// getConstraintColumns returns columns of a constraint, and foreign columns
+// they are referencing
+func (c completer) getConstraintColumns(con metadata.Constraint) ([]string, []string, error) {
+	r, ok := c.reader.(metadata.ConstraintColumnReader)
+	if !ok {
+		return nil, nil, text.ErrNotSupported
+	}
+	res, err := r.ConstraintColumns(metadata.Filter{Catalog: con.Catalog, Schema: con.Schema, Parent: con.Table, Name: con.Name})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Close()
+	var columns, foreignColumns []string
+	for res.Next() {
+		col := res.Get()
+		columns = append(columns, col.Name)
+		foreignColumns = append(foreignColumns, col.ForeignName)
+	}
+	return columns, foreignColumns, nil
+}
+
+// completeWithJoinables completes tables after JOIN, listing tables related
+// to already referenced tables by foreign keys first
+func (c completer) completeWithJoinables(text []rune, refs []tableRef) [][]rune {
+	selectables := c.getSelectables(text)
+	related := make(map[string]bool)
+	for _, ref := range refs {
+		fks, ok := c.getForeignKeys(ref)
+		if !ok {
+			for _, name := range c.guessRelatedTables(ref, selectables) {
+				related[name] = true
+			}
+			continue
+		}
+		for _, fk := range fks {
+			name := fk.foreignTable
+			if strings.EqualFold(name, ref.name) {
+				name = fk.table
+			}
+			related[name] = true
+		}
+	}
+	names := make([]string, 0, len(selectables))
+	for _, name := range selectables {
+		if related[name] {
+			names = append(names, name)
+		}
+	}
+	for _, name := range selectables {
+		if !related[name] {
+			names = append(names, name)
+		}
+	}
+	return CompleteFromList(text, names...)
+}
+
+// guessRelatedTables returns tables named like columns of ref, ie
+// customer for customer_id, or having a <table>_id column
+func (c completer) guessRelatedTables(ref tableRef, tables []string) []string {
+	columns := make(map[string]bool)
+	for _, col := range c.getColumns(ref) {
+		columns[strings.ToLower(col)] = true
+	}
+	var names []string
+	for _, table := range tables {
+		if !strings.EqualFold(table, ref.name) && (columns[idColumn(table)] || columns[idColumn(singular(table))]) {
+			names = append(names, table)
+		}
+	}
+	r, ok := c.reader.(metadata.ColumnReader)
+	if !ok || ref.derived {
+		return names
+	}
+	res, err := r.Columns(metadata.Filter{Catalog: ref.catalog, Schema: ref.schema, OnlyVisible: ref.schema == ""})
+	if err != nil {
+		return names
+	}
+	defer res.Close()
+	for res.Next() {
+		col := res.Get()
+		if name := strings.ToLower(col.Name); name == idColumn(ref.name) || name == idColumn(singular(ref.name)) {
+			names = append(names, col.Table)
+		}
+	}
+	return names
+}
+
+// findJoinedRef returns the index of the table joined by JOIN ... ON, or -1
+func findJoinedRef(refs []tableRef, previousWords []string) int {
+	if len(refs) < 2 ||
+		!TailMatches(IGNORE_CASE, previousWords, "JOIN", "*", "ON") &&
+			!TailMatches(IGNORE_CASE, previousWords, "JOIN", "*", "*", "ON") &&
+			!TailMatches(IGNORE_CASE, previousWords, "JOIN", "*", "AS", "*", "ON") {
+		return -1
+	}
+	// the word before ON is either the alias or the table name
+	i, _ := findQualifiedRef(refs, previousWords[1]+".")
+	return i
+}
+
+// completeWithJoinConditions completes JOIN ... ON with conditions joining
+// the joined table to other referenced tables, followed by their columns
+func (c completer) completeWithJoinConditions(text []rune, refs []tableRef, joined int) [][]rune {
+	var conditions []string
+	fks, ok := c.getForeignKeys(refs[joined])
+	if !ok {
+		for i, ref := range refs {
+			if i != joined {
+				conditions = append(conditions, c.guessJoinConditions(refs[joined], ref)...)
+			}
+		}
+	}
+	for _, fk := range fks {
+		for i, ref := range refs {
+			switch {
+			case i == joined:
+			case fk.matches(refs[joined], ref):
+				conditions = append(conditions, joinCondition(refs[joined], fk.columns, ref, fk.foreignColumns))
+			case fk.matches(ref, refs[joined]):
+				conditions = append(conditions, joinCondition(ref, fk.columns, refs[joined], fk.foreignColumns))
+			}
+		}
+	}
+	result := CompleteFromList(text, conditions...)
+	return append(result, c.completeWithColumns(text, refs)...)
+}
+
+// guessJoinConditions returns conditions joining <table>_id columns of one
+// table with id columns of the other
+func (c completer) guessJoinConditions(a, b tableRef) []string {
+	var conditions []string
+	aCols, bCols := c.getColumns(a), c.getColumns(b)
+	for i := 0; i < 2; i++ {
+		from, to, fromCols, toCols := a, b, aCols, bCols
+		if i == 1 {
+			from, to, fromCols, toCols = b, a, bCols, aCols
+		}
+		id := findColumn(toCols, "id")
+		if id == "" {
+			continue
+		}
+		for _, name := range []string{idColumn(to.name), idColumn(singular(to.name))} {
+			if col := findColumn(fromCols, name); col != "" {
+				conditions = append(conditions, joinCondition(from, []string{col}, to, []string{id}))
+				break
+			}
+		}
+	}
+	return conditions
+}
+
+// matches when the foreign key is from table to foreign
+func (fk foreignKey) matches(table, foreign tableRef) bool {
+	return strings.EqualFold(fk.table, table.name) && (table.schema == "" || strings.EqualFold(fk.schema, table.schema)) &&
+		strings.EqualFold(fk.foreignTable, foreign.name) && (foreign.schema == "" || strings.EqualFold(fk.foreignSchema, foreign.schema)) &&
+		len(fk.columns) != 0 && len(fk.columns) == len(fk.foreignColumns)
+}
+
+// joinCondition returns a condition comparing columns of both tables
+func joinCondition(a tableRef, aCols []string, b tableRef, bCols []string) string {
+	conds := make([]string, len(aCols))
+	for i := range aCols {
+		conds[i] = a.qualifier() + "." + aCols[i] + " = " + b.qualifier() + "." + bCols[i]
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// qualifier used to reference columns of the table
+func (ref tableRef) qualifier() string {
+	if ref.alias != "" {
+		return ref.alias
+	}
+	if ref.schema != "" {
+		return ref.schema + "." + ref.name
+	}
+	return ref.name
+}
+
+// findColumn returns the column equal to name, ignoring case
+func findColumn(columns []string, name string) string {
+	for _, col := range columns {
+		if strings.EqualFold(col, name) {
+			return col
+		}
+	}
+	return ""
+}
+
+func idColumn(table string) string {
+	return strings.ToLower(table) + "_id"
+}
+
+// singular of a (plural) table name, ie customer for customers
+func singular(table string) string {
+	return strings.TrimSuffix(table, "s")
+}
@@ -0,0 +1,448 @@
+package completer
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota
+	tokLiteral
+	tokPunct
+)
+
+// token of a SQL statement
+type token struct {
+	kind   tokenKind
+	text   string
+	quoted bool
+	pos    int
+}
+
+// is when the token is an unquoted identifier equal to any of the keywords
+func (t token) is(keywords ...string) bool {
+	if t.kind != tokIdent || t.quoted {
+		return false
+	}
+	for _, k := range keywords {
+		if strings.EqualFold(t.text, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPunct when the token is the punctuation s
+func (t token) isPunct(s string) bool {
+	return t.kind == tokPunct && t.text == s
+}
+
+// tokenize splits a statement into tokens, skipping whitespace and comments
+func tokenize(buf []rune) []token {
+	var tokens []token
+	for i := 0; i < len(buf); {
+		r := buf[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(buf) && buf[i+1] == '-':
+			for i < len(buf) && buf[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(buf) && buf[i+1] == '*':
+			for i += 2; i < len(buf) && !(buf[i-1] == '*' && buf[i] == '/'); i++ {
+			}
+			i++
+		case r == '\'':
+			s, end := readQuoted(buf, i, '\'')
+			tokens = append(tokens, token{kind: tokLiteral, text: s, pos: i})
+			i = end
+		case r == '"' || r == '`' || r == '[':
+			closing := r
+			if r == '[' {
+				closing = ']'
+			}
+			s, end := readQuoted(buf, i, closing)
+			tokens = append(tokens, token{kind: tokIdent, text: s, quoted: true, pos: i})
+			i = end
+		case isIdentRune(r):
+			start := i
+			for i < len(buf) && isIdentRune(buf[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(buf[start:i]), pos: start})
+		default:
+			tokens = append(tokens, token{kind: tokPunct, text: string(r), pos: i})
+			i++
+		}
+	}
+	return tokens
+}
+
+// readQuoted reads a quoted string starting at i, returning its unquoted
+// contents and the position after the closing quote
+func readQuoted(buf []rune, i int, closing rune) (string, int) {
+	var sb strings.Builder
+	for i++; i < len(buf); i++ {
+		if buf[i] == closing {
+			// doubled quotes are escaped quotes
+			if i+1 < len(buf) && buf[i+1] == closing {
+				sb.WriteRune(closing)
+				i++
+				continue
+			}
+			return sb.String(), i + 1
+		}
+		sb.WriteRune(buf[i])
+	}
+	return sb.String(), i
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$' || r == '#' || r == '@'
+}
+
+// currentStatement returns tokens of the statement containing point
+func currentStatement(line []rune, point int) []token {
+	tokens := tokenize(line)
+	start, end := 0, len(tokens)
+	for i, t := range tokens {
+		if !t.isPunct(";") {
+			continue
+		}
+		if t.pos < point {
+			start = i + 1
+		} else {
+			end = i
+			break
+		}
+	}
+	return tokens[start:end]
+}
+
+// tableRef is a table, view, CTE or subquery referenced by a statement
+type tableRef struct {
+	catalog string
+	schema  string
+	name    string
+	quoted  bool
+	alias   string
+	aliasQ  bool
+	// columns of CTEs, subqueries or aliases with column lists
+	columns []string
+	derived bool
+}
+
+// aliasKeywords are keywords that can follow a table name, but are not its alias
+var aliasKeywords = []string{
+	"AND", "AS", "CONNECT", "CROSS", "DO", "EXCEPT", "FETCH", "FOR", "FROM",
+	"FULL", "GROUP", "HAVING", "INNER", "INTERSECT", "JOIN", "LATERAL", "LEFT",
+	"LIMIT", "MINUS", "NATURAL", "OFFSET", "ON", "OR", "ORDER", "OUTER",
+	"PIVOT", "QUALIFY", "RETURNING", "RIGHT", "SELECT", "SET", "START",
+	"TABLESAMPLE", "UNION", "UNPIVOT", "USING", "VALUES", "WHERE", "WINDOW",
+	"WITH",
+}
+
+// findTableRefs returns tables referenced in FROM and JOIN clauses (as well
+// as INSERT INTO and UPDATE) of the statement containing point
+func findTableRefs(line []rune, point int) []tableRef {
+	tokens := currentStatement(line, point)
+	ctes := parseCTEs(tokens)
+	var refs []tableRef
+	// subqueries tracks whether each open parenthesis starts a subquery
+	var subqueries []bool
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.isPunct("("):
+			subqueries = append(subqueries, i+1 < len(tokens) && tokens[i+1].is("SELECT", "WITH", "VALUES"))
+			continue
+		case t.isPunct(")"):
+			if len(subqueries) != 0 {
+				subqueries = subqueries[:len(subqueries)-1]
+			}
+			continue
+		case t.is("FROM", "JOIN"):
+		case t.is("INTO"):
+			if i == 0 || !tokens[i-1].is("INSERT", "MERGE") {
+				continue
+			}
+		case t.is("UPDATE"):
+			if i != 0 && tokens[i-1].is("FOR", "DO") {
+				continue
+			}
+		default:
+			continue
+		}
+		// skip FROM in function arguments, ie EXTRACT(x FROM y)
+		if len(subqueries) != 0 && !subqueries[len(subqueries)-1] {
+			continue
+		}
+		for j := i + 1; j < len(tokens); j++ {
+			ref, next, ok := parseTableRef(tokens, j)
+			if !ok {
+				break
+			}
+			if ref.schema == "" && !ref.derived {
+				if cte, ok := ctes[strings.ToLower(ref.name)]; ok {
+					ref.columns, ref.derived = cte.columns, true
+				}
+			}
+			refs = append(refs, ref)
+			if next >= len(tokens) || !tokens[next].isPunct(",") {
+				break
+			}
+			j = next
+		}
+	}
+	return refs
+}
+
+// parseTableRef parses a table reference starting at i, returning the
+// position after it
+func parseTableRef(tokens []token, i int) (tableRef, int, bool) {
+	for i < len(tokens) && tokens[i].is("LATERAL", "ONLY") {
+		i++
+	}
+	if i >= len(tokens) {
+		return tableRef{}, i, false
+	}
+	var ref tableRef
+	switch t := tokens[i]; {
+	case t.isPunct("("):
+		end := closingParen(tokens, i)
+		body := tokens[i+1 : end]
+		if len(body) == 0 || !body[0].is("SELECT", "WITH", "VALUES") {
+			return tableRef{}, i, false
+		}
+		ref.columns, ref.derived = selectColumns(body), true
+		i = end + 1
+	case t.kind == tokIdent && !t.is(aliasKeywords...):
+		parts := []token{t}
+		for i += 1; i+1 < len(tokens) && tokens[i].isPunct(".") && tokens[i+1].kind == tokIdent; i += 2 {
+			parts = append(parts, tokens[i+1])
+		}
+		last := parts[len(parts)-1]
+		ref.name, ref.quoted = last.text, last.quoted
+		if len(parts) > 1 {
+			ref.schema = parts[len(parts)-2].text
+		}
+		if len(parts) > 2 {
+			ref.catalog = parts[len(parts)-3].text
+		}
+		// table functions have unknown columns
+		if i < len(tokens) && tokens[i].isPunct("(") {
+			ref.derived = true
+			i = closingParen(tokens, i) + 1
+		}
+	default:
+		return tableRef{}, i, false
+	}
+	// alias
+	if i < len(tokens) && tokens[i].is("AS") {
+		i++
+	}
+	if i < len(tokens) && tokens[i].kind == tokIdent && !tokens[i].is(aliasKeywords...) {
+		ref.alias, ref.aliasQ = tokens[i].text, tokens[i].quoted
+		i++
+		// column aliases
+		if i < len(tokens) && tokens[i].isPunct("(") {
+			end := closingParen(tokens, i)
+			ref.columns, ref.derived = identList(tokens[i+1:end]), true
+			i = end + 1
+		}
+	}
+	return ref, i, true
+}
+
+// parseCTEs parses common table expressions of a WITH clause, indexed by
+// their lower case names
+func parseCTEs(tokens []token) map[string]tableRef {
+	ctes := make(map[string]tableRef)
+	if len(tokens) == 0 || !tokens[0].is("WITH") {
+		return ctes
+	}
+	i := 1
+	if i < len(tokens) && tokens[i].is("RECURSIVE") {
+		i++
+	}
+	for i < len(tokens) && tokens[i].kind == tokIdent {
+		cte := tableRef{name: tokens[i].text, quoted: tokens[i].quoted, derived: true}
+		i++
+		explicit := false
+		if i < len(tokens) && tokens[i].isPunct("(") {
+			end := closingParen(tokens, i)
+			cte.columns, explicit = identList(tokens[i+1:end]), true
+			i = end + 1
+		}
+		for i < len(tokens) && tokens[i].is("AS", "NOT", "MATERIALIZED") {
+			i++
+		}
+		if i >= len(tokens) || !tokens[i].isPunct("(") {
+			break
+		}
+		end := closingParen(tokens, i)
+		if !explicit {
+			cte.columns = selectColumns(tokens[i+1 : end])
+		}
+		ctes[strings.ToLower(cte.name)] = cte
+		i = end + 1
+		if i >= len(tokens) || !tokens[i].isPunct(",") {
+			break
+		}
+		i++
+	}
+	return ctes
+}
+
+// selectColumns returns names of columns selected by a query, where they can
+// be determined
+func selectColumns(tokens []token) []string {
+	// skip to the select list of the main query
+	i, depth := 0, 0
+	for ; i < len(tokens) && (depth != 0 || !tokens[i].is("SELECT")); i++ {
+		switch {
+		case tokens[i].isPunct("("):
+			depth++
+		case tokens[i].isPunct(")"):
+			depth--
+		}
+	}
+	if i == len(tokens) {
+		return nil
+	}
+	for i++; i < len(tokens) && tokens[i].is("DISTINCT", "ALL"); i++ {
+	}
+	var columns []string
+	var item []token
+	add := func() {
+		// the last identifier is either the column name or its alias
+		if n := len(item); n != 0 && item[n-1].kind == tokIdent && !item[n-1].is("END") {
+			columns = append(columns, item[n-1].text)
+		}
+		item = nil
+	}
+	for depth = 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch {
+		case t.isPunct("("):
+			depth++
+		case t.isPunct(")"):
+			depth--
+		case depth == 0 && t.isPunct(","):
+			add()
+			continue
+		case depth == 0 && t.is("FROM", "INTO", "WHERE", "GROUP", "HAVING", "ORDER", "LIMIT", "UNION", "INTERSECT", "EXCEPT", "MINUS"):
+			add()
+			return columns
+		}
+		if depth == 0 {
+			item = append(item, t)
+		}
+	}
+	add()
+	return columns
+}
+
+// identList returns identifiers of a comma separated list
+func identList(tokens []token) []string {
+	var names []string
+	for _, t := range tokens {
+		if t.kind == tokIdent {
+			names = append(names, t.text)
+		}
+	}
+	return names
+}
+
+// closingParen returns the position of the parenthesis closing the one at
+// i, or the end of tokens
+func closingParen(tokens []token, i int) int {
+	depth := 0
+	for ; i < len(tokens); i++ {
+		switch {
+		case tokens[i].isPunct("("):
+			depth++
+		case tokens[i].isPunct(")"):
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens)
+}
+
+// findQualifiedRef returns the index of the table reference matching the
+// qualifier of text, ie o in o.name, and the qualifier, or -1
+func findQualifiedRef(refs []tableRef, text string) (int, string) {
+	pos := strings.LastIndex(text, ".")
+	if pos == -1 {
+		return -1, ""
+	}
+	var parts []token
+	for i, t := range tokenize([]rune(text[:pos])) {
+		if (i%2 == 0 && t.kind != tokIdent) || (i%2 == 1 && !t.isPunct(".")) {
+			return -1, ""
+		}
+		if i%2 == 0 {
+			parts = append(parts, t)
+		}
+	}
+	if len(parts) == 0 || len(parts) > 3 {
+		return -1, ""
+	}
+	for i, ref := range refs {
+		if ref.alias != "" {
+			if len(parts) == 1 && identEqual(parts[0], ref.alias, ref.aliasQ) {
+				return i, text[:pos]
+			}
+			continue
+		}
+		names := []string{ref.catalog, ref.schema, ref.name}[3-len(parts):]
+		matched := true
+		for j := 0; matched && j < len(parts); j++ {
+			matched = identEqual(parts[j], names[j], ref.quoted && j == len(parts)-1)
+		}
+		if matched {
+			return i, text[:pos]
+		}
+	}
+	return -1, ""
+}
+
+// identEqual compares identifiers, ignoring case unless either is quoted
+func identEqual(t token, name string, quoted bool) bool {
+	if t.quoted || quoted {
+		return t.text == name
+	}
+	return strings.EqualFold(t.text, name)
+}
+
+// isColumnPosition when the next word can be a column of a referenced table,
+// ie in SELECT lists, and WHERE, GROUP BY, ORDER BY, HAVING or ON clauses
+func isColumnPosition(previousWords []string) bool {
+	if len(previousWords) == 0 {
+		return false
+	}
+	switch prev := strings.ToUpper(previousWords[0]); {
+	case prev == "(":
+		return len(previousWords) < 2 || !wordMatches(IGNORE_CASE, "FROM|JOIN", previousWords[1])
+	case prev == "BY":
+		return TailMatches(IGNORE_CASE, previousWords, "GROUP|ORDER|PARTITION", "BY")
+	case strings.HasSuffix(prev, ","):
+		// find the clause of the comma separated list
+		for _, w := range previousWords[1:] {
+			switch strings.ToUpper(w) {
+			case "SELECT", "DISTINCT", "BY", "RETURNING":
+				return true
+			case "FROM", "JOIN", "WHERE", "ON", "HAVING", "SET", "VALUES", "INTO", "USING", "WITH":
+				return false
+			}
+		}
+		return false
+	}
+	return wordMatches(IGNORE_CASE, "SELECT|DISTINCT|WHERE|ON|HAVING|AND|OR|NOT|CASE|WHEN|THEN|ELSE|RETURNING|=|<|>|<=|>=|<>|!=|+|-|/", previousWords[0])
+}
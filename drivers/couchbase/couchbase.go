@@ -13,6 +13,8 @@ import (
 func init() {
 	drivers.Register("n1ql", drivers.Driver{
 		AllowMultilineComments: true,
+		AllowBackslashEscapes:  true,
+		AllowBackticks:         true,
 		Err: func(err error) (string, string) {
 			return "", strings.TrimPrefix(err.Error(), "N1QL: ")
 		},
@@ -18,6 +18,8 @@ func init() {
 	csvq.SetStdout(query.NewDiscard())
 	drivers.Register("csvq", drivers.Driver{
 		AllowMultilineComments: true,
+		AllowBackslashEscapes:  true,
+		AllowBackticks:         true,
 		Process: func(prefix string, sqlstr string) (string, string, bool, error) {
 			typ, q := drivers.QueryExecType(prefix, sqlstr)
 			if strings.HasPrefix(prefix, "SHOW") {
@@ -33,6 +35,7 @@ func init() {
 			}
 			return "CSVQ " + ver, nil
 		},
-		Copy: drivers.CopyWithInsert(func(int) string { return "?" }),
+		Copy:              drivers.CopyWithInsert(func(int) string { return "?" }),
+		NewMetadataReader: NewMetadataReader,
 	})
 }
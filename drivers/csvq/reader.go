@@ -0,0 +1,338 @@
+package csvq
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/metadata"
+)
+
+type MetadataReader struct {
+	metadata.LoggingReader
+}
+
+// NewMetadataReader creates the metadata reader for csvq databases, listing
+// files in the repository directory as tables.
+func NewMetadataReader(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
+	return &MetadataReader{
+		LoggingReader: metadata.NewLoggingReader(db, opts...),
+	}
+}
+
+var (
+	_ metadata.BasicReader    = &MetadataReader{}
+	_ metadata.TableRowReader = &MetadataReader{}
+)
+
+// sampleSize is the number of rows used to infer column types.
+const sampleSize = 100
+
+// formats of files by extension, for files csvq can load without specifying
+// the format.
+var formats = map[string]string{
+	".csv":   "CSV",
+	".tsv":   "TSV",
+	".json":  "JSON",
+	".jsonl": "JSONL",
+	".ltsv":  "LTSV",
+	".txt":   "",
+}
+
+// datetimeLayouts are layouts of strings inferred as DATETIME values.
+var datetimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02 15:04:05.999999999",
+	"2006/01/02",
+}
+
+// tableFile is a file of the repository queried as a table.
+type tableFile struct {
+	name      string
+	file      string
+	format    string
+	encoding  string
+	delimiter rune
+	size      int64
+}
+
+// Schemas returns no schemas, since csvq repositories do not have any.
+func (r MetadataReader) Schemas(metadata.Filter) (*metadata.SchemaSet, error) {
+	return metadata.NewSchemaSet([]metadata.Schema{}), nil
+}
+
+// Tables lists files in the repository, with their format, encoding and
+// delimiter. Rows are counted by TableRows.
+func (r MetadataReader) Tables(f metadata.Filter) (*metadata.TableSet, error) {
+	if !metadata.HasType(f.Types, "TABLE") {
+		return metadata.NewTableSet([]metadata.Table{}), nil
+	}
+	files, err := r.files(f.Name)
+	if err != nil {
+		return nil, err
+	}
+	var results []metadata.Table
+	for _, file := range files {
+		rec := metadata.Table{
+			Name:    file.name,
+			Type:    "TABLE",
+			Size:    fmt.Sprintf("%d kB", int64(math.Round(float64(file.size)/1024))),
+			Comment: file.comment(),
+		}
+		results = append(results, rec)
+	}
+	return metadata.NewTableSet(results), nil
+}
+
+// TableRows counts rows of a file, which requires reading all of it, so it is
+// only done when listing tables verbosely.
+func (r MetadataReader) TableRows(t metadata.Table) (int64, error) {
+	files, err := r.files(t.Name)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, file := range files {
+		if file.name != t.Name {
+			continue
+		}
+		rows, closeRows, err := r.Query("SELECT COUNT(*) FROM " + file.ident())
+		if err != nil {
+			return 0, err
+		}
+		defer closeRows()
+		for rows.Next() {
+			if err := rows.Scan(&count); err != nil {
+				return 0, err
+			}
+		}
+		return count, rows.Err()
+	}
+	return count, nil
+}
+
+// Columns returns fields of files, with types inferred from sampled values.
+func (r MetadataReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
+	files, err := r.files(f.Parent)
+	if err != nil {
+		return nil, err
+	}
+	match := metadata.LikeMatcher(f.Name)
+	var results []metadata.Column
+	for _, file := range files {
+		cols, err := r.columns(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range cols {
+			if match(col.Name) {
+				results = append(results, col)
+			}
+		}
+	}
+	return metadata.NewColumnSet(results), nil
+}
+
+// columns returns fields of a file, sampling its first rows.
+func (r MetadataReader) columns(file tableFile) ([]metadata.Column, error) {
+	rows, closeRows, err := r.Query(fmt.Sprintf("SELECT * FROM %s LIMIT %d", file.ident(), sampleSize))
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	types, nulls := make([]string, len(names)), make([]bool, len(names))
+	vals, ptrs := make([]interface{}, len(names)), make([]interface{}, len(names))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range vals {
+			if v == nil {
+				nulls[i] = true
+				continue
+			}
+			types[i] = mergeTypes(types[i], valueType(v))
+		}
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	cols := make([]metadata.Column, len(names))
+	for i, name := range names {
+		cols[i] = metadata.Column{
+			Table:           file.name,
+			Name:            name,
+			OrdinalPosition: i + 1,
+			DataType:        types[i],
+			IsNullable:      metadata.NO,
+		}
+		if types[i] == "" {
+			cols[i].DataType = "STRING"
+		}
+		if nulls[i] {
+			cols[i].IsNullable = metadata.YES
+		}
+	}
+	return cols, nil
+}
+
+// files returns files of the repository with names, without extensions,
+// matching the pattern, sorted by name.
+func (r MetadataReader) files(pattern string) ([]tableFile, error) {
+	rows, closeRows, err := r.Query(`SELECT @@REPOSITORY, @@ENCODING, @@DELIMITER, @@IMPORT_FORMAT`)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var repo, encoding, delimiter, importFormat string
+	for rows.Next() {
+		if err := rows.Scan(&repo, &encoding, &delimiter, &importFormat); err != nil {
+			return nil, err
+		}
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	if repo == "" {
+		repo = "."
+	}
+	entries, err := os.ReadDir(repo)
+	if err != nil {
+		return nil, err
+	}
+	match := metadata.LikeMatcher(pattern)
+	var files []tableFile
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		format, ok := formats[ext]
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if entry.IsDir() || !ok || strings.HasPrefix(entry.Name(), ".") || !match(name) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		file := tableFile{
+			name:     name,
+			file:     entry.Name(),
+			format:   format,
+			encoding: encoding,
+			size:     info.Size(),
+		}
+		if file.format == "" {
+			file.format = importFormat
+		}
+		switch file.format {
+		case "CSV":
+			if d := []rune(delimiter); len(d) != 0 {
+				file.delimiter = d[0]
+			}
+		case "TSV":
+			file.delimiter = '\t'
+		}
+		if file.encoding == "AUTO" {
+			file.encoding = detectEncoding(filepath.Join(repo, entry.Name()))
+		}
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].file < files[j].file
+	})
+	return files, nil
+}
+
+// ident returns the quoted file name.
+func (file tableFile) ident() string {
+	return "`" + strings.ReplaceAll(file.file, "`", "\\`") + "`"
+}
+
+// comment returns the format, encoding and delimiter of the file.
+func (file tableFile) comment() string {
+	s := file.format + ", " + file.encoding
+	if file.delimiter != 0 {
+		s += ", delimiter " + strconv.QuoteRune(file.delimiter)
+	}
+	return s
+}
+
+// detectEncoding returns the encoding of a file by its byte order mark.
+func detectEncoding(name string) string {
+	f, err := os.Open(name)
+	if err != nil {
+		return "UTF8"
+	}
+	defer f.Close()
+	buf := make([]byte, 3)
+	n, _ := f.Read(buf)
+	switch buf = buf[:n]; {
+	case bytes.HasPrefix(buf, []byte{0xef, 0xbb, 0xbf}):
+		return "UTF8M"
+	case bytes.HasPrefix(buf, []byte{0xfe, 0xff}):
+		return "UTF16BEM"
+	case bytes.HasPrefix(buf, []byte{0xff, 0xfe}):
+		return "UTF16LEM"
+	}
+	return "UTF8"
+}
+
+// valueType returns the csvq type of a value.
+func valueType(v interface{}) string {
+	switch x := v.(type) {
+	case int64:
+		return "INTEGER"
+	case float64:
+		if x == math.Trunc(x) {
+			return "INTEGER"
+		}
+		return "FLOAT"
+	case bool:
+		return "BOOLEAN"
+	case time.Time:
+		return "DATETIME"
+	case string:
+		if _, err := strconv.ParseInt(x, 10, 64); err == nil {
+			return "INTEGER"
+		}
+		if _, err := strconv.ParseFloat(x, 64); err == nil {
+			return "FLOAT"
+		}
+		if strings.EqualFold(x, "true") || strings.EqualFold(x, "false") {
+			return "BOOLEAN"
+		}
+		for _, layout := range datetimeLayouts {
+			if _, err := time.Parse(layout, x); err == nil {
+				return "DATETIME"
+			}
+		}
+	}
+	return "STRING"
+}
+
+// mergeTypes returns the type of a column having values of both types.
+func mergeTypes(a, b string) string {
+	switch {
+	case a == "" || a == b:
+		return b
+	case a == "INTEGER" && b == "FLOAT", a == "FLOAT" && b == "INTEGER":
+		return "FLOAT"
+	}
+	return "STRING"
+}
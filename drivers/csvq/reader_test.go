@@ -0,0 +1,97 @@
+package csvq
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/xo/usql/drivers/metadata"
+)
+
+func newReader(t *testing.T) *MetadataReader {
+	t.Helper()
+	db, err := sql.Open("csvq", "testdata")
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewMetadataReader(db).(*MetadataReader)
+}
+
+func TestTables(t *testing.T) {
+	r := newReader(t)
+	tests := []struct {
+		filter metadata.Filter
+		exp    string
+	}{
+		{metadata.Filter{}, "actor:TSV, UTF8, delimiter '\\t' category:JSON, UTF8 film:CSV, UTF8, delimiter ','"},
+		{metadata.Filter{Name: "f%"}, "film:CSV, UTF8, delimiter ','"},
+		{metadata.Filter{Name: "film.csv"}, ""},
+		{metadata.Filter{Types: []string{"VIEW"}}, ""},
+	}
+	for i, test := range tests {
+		res, err := r.Tables(test.filter)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		var tables []string
+		for res.Next() {
+			table := res.Get()
+			if table.Rows != 0 {
+				t.Errorf("test %d expected rows of %s not to be counted, got: %d", i, table.Name, table.Rows)
+			}
+			tables = append(tables, table.Name+":"+table.Comment)
+		}
+		if s := strings.Join(tables, " "); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+}
+
+func TestTableRows(t *testing.T) {
+	r := newReader(t)
+	tests := []struct {
+		name string
+		exp  int64
+	}{
+		{"actor", 2},
+		{"category", 2},
+		{"film", 3},
+		{"missing", 0},
+	}
+	for i, test := range tests {
+		rows, err := r.TableRows(metadata.Table{Name: test.name})
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		if rows != test.exp {
+			t.Errorf("test %d expected %d rows, got: %d", i, test.exp, rows)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	r := newReader(t)
+	tests := []struct {
+		filter metadata.Filter
+		exp    string
+	}{
+		{metadata.Filter{Parent: "film"}, "film_id INTEGER NO, title STRING NO, length INTEGER YES, rental_rate FLOAT NO, available BOOLEAN NO, last_update DATETIME NO"},
+		{metadata.Filter{Parent: "category"}, "category_id INTEGER NO, name STRING NO, parent INTEGER YES"},
+		{metadata.Filter{Parent: "a%", Name: "%name"}, "first_name STRING NO, last_name STRING NO"},
+	}
+	for i, test := range tests {
+		res, err := r.Columns(test.filter)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		var cols []string
+		for res.Next() {
+			c := res.Get()
+			cols = append(cols, c.Name+" "+c.DataType+" "+string(c.IsNullable))
+		}
+		if s := strings.Join(cols, ", "); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+}
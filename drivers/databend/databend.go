@@ -24,8 +24,10 @@ func init() {
 		infos.WithColumnPrivileges(false),
 	)
 	drivers.Register("databend", drivers.Driver{
-		UseColumnTypes:    true,
-		NewMetadataReader: newReader,
+		AllowBackslashEscapes: true,
+		AllowBackticks:        true,
+		UseColumnTypes:        true,
+		NewMetadataReader:     newReader,
 		NewMetadataWriter: func(db drivers.DB, w io.Writer, opts ...metadata.ReaderOption) metadata.Writer {
 			return metadata.NewDefaultWriter(newReader(db, opts...))(db, w)
 		},
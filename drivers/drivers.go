@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"regexp"
 	"strings"
 	"time"
 	"unicode"
@@ -19,6 +20,7 @@ import (
 	"github.com/xo/dburl"
 	"github.com/xo/usql/drivers/completer"
 	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/drivers/plan"
 	"github.com/xo/usql/stmt"
 	"github.com/xo/usql/text"
 )
@@ -52,6 +54,35 @@ type Driver struct {
 	// AllowHashComments will be passed to query buffers to enable hash (#)
 	// style comments.
 	AllowHashComments bool
+	// AllowBackslashEscapes will be passed to query buffers to enable
+	// backslash escapes in single quoted strings (ie, 'it\'s').
+	AllowBackslashEscapes bool
+	// AllowBackticks will be passed to query buffers to enable backtick quoted
+	// identifiers (ie, `name`).
+	AllowBackticks bool
+	// AllowBrackets will be passed to query buffers to enable bracket quoted
+	// identifiers (ie, [name]).
+	AllowBrackets bool
+	// AllowQQuotes will be passed to query buffers to enable alternative
+	// quoted strings (ie, q'[it's]').
+	AllowQQuotes bool
+	// AllowEStrings will be passed to query buffers to enable escape strings
+	// (ie, E'it\'s').
+	AllowEStrings bool
+	// BatchSeparator will be passed to query buffers to terminate statements
+	// with a batch separator (ie, GO) alone on a line, optionally followed by a
+	// repeat count, instead of semicolons.
+	BatchSeparator string
+	// AllowPLSQL will be passed to query buffers to enable PL/SQL blocks,
+	// terminated by a line containing only a slash (ie, /).
+	AllowPLSQL bool
+	// AllowDelimiter will be passed to query buffers to enable the delimiter
+	// command (ie, DELIMITER $$) that changes the statement terminator.
+	AllowDelimiter bool
+	// AllowBlocks will be passed to query buffers to enable compound statement
+	// blocks (ie, BEGIN ... END) in CREATE TRIGGER, PROCEDURE, and FUNCTION
+	// statements.
+	AllowBlocks bool
 	// RequirePreviousPassword will be used by RequirePreviousPassword.
 	RequirePreviousPassword bool
 	// LexerName is the name of the syntax lexer to use.
@@ -102,8 +133,78 @@ type Driver struct {
 	NewMetadataWriter func(db DB, w io.Writer, opts ...metadata.ReaderOption) metadata.Writer
 	// NewCompleter returns a db auto-completer.
 	NewCompleter func(db DB, opts ...completer.Option) readline.AutoCompleter
+	// Dialect is the SQL dialect completed by the auto-completer, in addition
+	// to keywords and functions of the syntax lexer.
+	Dialect completer.Dialect
 	// Copy rows into the database table
 	Copy func(ctx context.Context, db *sql.DB, rows *sql.Rows, table string) (int64, error)
+	// Setup will be used by Setup to prepare the session before executing a
+	// statement, if defined.
+	Setup func(context.Context, DB) error
+	// Output will be used by Output to write output buffered by the database
+	// after executing a statement, if defined.
+	Output func(context.Context, DB, io.Writer) error
+	// QueryMessages will be used by QueryMessages to execute statements and
+	// queries, if defined.
+	QueryMessages func(context.Context, DB, io.Writer, string) (MessageRows, error)
+	// NewListener will be used by NewListener to listen for asynchronous
+	// notifications, if defined.
+	NewListener func(context.Context, *dburl.URL, *sql.DB) (Listener, error)
+	// CopyIO will be used by CopyIO to pass COPY ... TO STDOUT and COPY ...
+	// FROM STDIN statements through to the database, if defined.
+	CopyIO func(context.Context, DB, string, io.Reader, io.Writer) (int64, error)
+	// Explain will be used by Explain to retrieve the query plan of a query,
+	// if defined.
+	Explain func(context.Context, plan.DB, string, bool) (*plan.Node, error)
+	// Warnings will be used by Warnings to retrieve the warning count and
+	// warnings raised by the last executed statement, if defined.
+	Warnings func(context.Context, DB) (int64, []Warning, error)
+}
+
+// Warning is a warning raised by the database while executing a statement.
+type Warning struct {
+	Level   string
+	Code    int64
+	Message string
+}
+
+// Notification is an asynchronous notification received from the database.
+type Notification struct {
+	Channel string
+	Payload string
+	PID     int
+}
+
+// Listener is the interface for a dedicated database connection listening
+// for asynchronous notifications.
+type Listener interface {
+	// Listen starts listening for notifications on the channel.
+	Listen(context.Context, string) error
+	// Unlisten stops listening for notifications on the channel, or on all
+	// channels when empty.
+	Unlisten(context.Context, string) error
+	// Wait blocks until the next notification is received.
+	Wait(context.Context) (*Notification, error)
+	// Close closes the listener.
+	Close() error
+}
+
+// MessageRows is the interface for rows of a statement executed by a driver,
+// that writes informational messages from the database in order with its
+// result sets.
+//
+// NextResultSet must be called before reading the first result set, and
+// returns false when there are no more result sets.
+type MessageRows interface {
+	Next() bool
+	Scan(...interface{}) error
+	Columns() ([]string, error)
+	Close() error
+	Err() error
+	NextResultSet() bool
+	// RowsAffected returns the count of rows affected by the statement, after
+	// all result sets have been read.
+	RowsAffected() int64
 }
 
 // drivers are registered drivers.
@@ -188,6 +289,15 @@ func stmtOpts(u *dburl.URL) []stmt.Option {
 				stmt.WithAllowMultilineComments(d.AllowMultilineComments),
 				stmt.WithAllowCComments(d.AllowCComments),
 				stmt.WithAllowHashComments(d.AllowHashComments),
+				stmt.WithAllowBackslashEscapes(d.AllowBackslashEscapes),
+				stmt.WithAllowBackticks(d.AllowBackticks),
+				stmt.WithAllowBrackets(d.AllowBrackets),
+				stmt.WithAllowQQuotes(d.AllowQQuotes),
+				stmt.WithAllowEStrings(d.AllowEStrings),
+				stmt.WithBatchSeparator(d.BatchSeparator),
+				stmt.WithAllowPLSQL(d.AllowPLSQL),
+				stmt.WithAllowDelimiter(d.AllowDelimiter),
+				stmt.WithAllowBlocks(d.AllowBlocks),
 			}
 		}
 	}
@@ -196,6 +306,7 @@ func stmtOpts(u *dburl.URL) []stmt.Option {
 		stmt.WithAllowMultilineComments(true),
 		stmt.WithAllowCComments(true),
 		stmt.WithAllowHashComments(true),
+		stmt.WithAllowBackslashEscapes(true),
 	}
 }
 
@@ -414,6 +525,170 @@ func Ping(ctx context.Context, u *dburl.URL, db *sql.DB) error {
 	return WrapErr(u.Driver, db.PingContext(ctx))
 }
 
+// Session returns a single connection of the database, when the driver
+// prepares the session of statements, or retrieves their output or warnings,
+// so that statements and the Setup, Output and Warnings funcs of the driver
+// use the same session. Otherwise, or when already a single session (ie, a
+// transaction), returns db. The returned func releases the connection.
+func Session(ctx context.Context, u *dburl.URL, db DB) (DB, func() error, error) {
+	d, ok := drivers[u.Driver]
+	sqldb, isDB := db.(*sql.DB)
+	if !ok || !isDB || d.Setup == nil && d.Output == nil && d.Warnings == nil {
+		return db, func() error { return nil }, nil
+	}
+	conn, err := sqldb.Conn(ctx)
+	if err != nil {
+		return nil, nil, WrapErr(u.Driver, err)
+	}
+	return sessionConn{conn}, conn.Close, nil
+}
+
+// sessionConn wraps a single connection as a DB.
+type sessionConn struct {
+	*sql.Conn
+}
+
+// Exec satisfies the DB interface.
+func (c sessionConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.ExecContext(context.Background(), query, args...)
+}
+
+// Query satisfies the DB interface.
+func (c sessionConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.QueryContext(context.Background(), query, args...)
+}
+
+// QueryRow satisfies the DB interface.
+func (c sessionConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.QueryRowContext(context.Background(), query, args...)
+}
+
+// Prepare satisfies the DB interface.
+func (c sessionConn) Prepare(query string) (*sql.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// Setup prepares the session for executing a statement for a driver.
+func Setup(ctx context.Context, u *dburl.URL, db DB) error {
+	if d, ok := drivers[u.Driver]; ok && d.Setup != nil {
+		return WrapErr(u.Driver, d.Setup(ctx, db))
+	}
+	return nil
+}
+
+// Output writes output buffered by the database during the last executed
+// statement for a driver.
+func Output(ctx context.Context, u *dburl.URL, db DB, w io.Writer) error {
+	if d, ok := drivers[u.Driver]; ok && d.Output != nil {
+		return WrapErr(u.Driver, d.Output(ctx, db, w))
+	}
+	return nil
+}
+
+// HasWarnings returns whether or not a driver can retrieve the warnings raised
+// by the last executed statement.
+func HasWarnings(u *dburl.URL) bool {
+	if u == nil {
+		return false
+	}
+	d, ok := drivers[u.Driver]
+	return ok && d.Warnings != nil
+}
+
+// Warnings retrieves the warning count and warnings raised by the last
+// executed statement for a driver.
+func Warnings(ctx context.Context, u *dburl.URL, db DB) (int64, []Warning, error) {
+	d, ok := drivers[u.Driver]
+	if !ok || d.Warnings == nil {
+		return 0, nil, fmt.Errorf(text.NotSupportedByDriver, "warnings", u.Driver)
+	}
+	count, warnings, err := d.Warnings(ctx, db)
+	if err != nil {
+		return 0, nil, WrapErr(u.Driver, err)
+	}
+	return count, warnings, nil
+}
+
+// HasQueryMessages returns whether or not a driver executes statements with
+// QueryMessages.
+func HasQueryMessages(u *dburl.URL) bool {
+	if u == nil {
+		return false
+	}
+	d, ok := drivers[u.Driver]
+	return ok && d.QueryMessages != nil
+}
+
+// copyIORE matches COPY statements reading from standard input or writing to
+// standard output.
+var copyIORE = regexp.MustCompile(`(?is)^\s*COPY\b.*\b(FROM\s+STDIN|TO\s+STDOUT)\b`)
+
+// IsCopyIO returns whether or not a driver passes the COPY statement through
+// to the database with CopyIO.
+func IsCopyIO(u *dburl.URL, prefix, sqlstr string) bool {
+	if u == nil || !strings.HasPrefix(prefix, "COPY") {
+		return false
+	}
+	d, ok := drivers[u.Driver]
+	return ok && d.CopyIO != nil && copyIORE.MatchString(sqlstr)
+}
+
+// CopyIO passes a COPY ... TO STDOUT or COPY ... FROM STDIN statement through
+// to the database for a driver, writing copied data to w, or reading copied
+// data from r.
+func CopyIO(ctx context.Context, u *dburl.URL, db DB, sqlstr string, r io.Reader, w io.Writer) (int64, error) {
+	d, ok := drivers[u.Driver]
+	if !ok || d.CopyIO == nil {
+		return 0, fmt.Errorf(text.NotSupportedByDriver, "COPY", u.Driver)
+	}
+	n, err := d.CopyIO(ctx, db, sqlstr, r, w)
+	if err != nil {
+		return n, WrapErr(u.Driver, err)
+	}
+	return n, nil
+}
+
+// Explain returns the query plan of a query for a driver, executing the query
+// when analyze is true.
+func Explain(ctx context.Context, u *dburl.URL, db DB, sqlstr string, analyze bool) (*plan.Node, error) {
+	d, ok := drivers[u.Driver]
+	if !ok || d.Explain == nil {
+		return nil, fmt.Errorf(text.NotSupportedByDriver, `\explain`, u.Driver)
+	}
+	n, err := d.Explain(ctx, db, sqlstr, analyze)
+	if err != nil {
+		return nil, WrapErr(u.Driver, err)
+	}
+	return n, nil
+}
+
+// QueryMessages executes a statement or query for a driver, writing
+// informational messages from the database to w.
+func QueryMessages(ctx context.Context, u *dburl.URL, db DB, w io.Writer, sqlstr string) (MessageRows, error) {
+	d, ok := drivers[u.Driver]
+	if !ok || d.QueryMessages == nil {
+		return nil, fmt.Errorf(text.NotSupportedByDriver, "messages", u.Driver)
+	}
+	rows, err := d.QueryMessages(ctx, db, w, sqlstr)
+	if err != nil {
+		return nil, WrapErr(u.Driver, err)
+	}
+	return rows, nil
+}
+
+// NewListener creates a listener for asynchronous notifications for a driver.
+func NewListener(ctx context.Context, u *dburl.URL, db *sql.DB) (Listener, error) {
+	d, ok := drivers[u.Driver]
+	if !ok || d.NewListener == nil {
+		return nil, fmt.Errorf(text.NotSupportedByDriver, `\listen`, u.Driver)
+	}
+	l, err := d.NewListener(ctx, u, db)
+	if err != nil {
+		return nil, WrapErr(u.Driver, err)
+	}
+	return l, nil
+}
+
 // Lexer returns the syntax lexer for a driver.
 func Lexer(u *dburl.URL) chroma.Lexer {
 	var l chroma.Lexer
@@ -478,23 +753,43 @@ func NewCompleter(ctx context.Context, u *dburl.URL, db DB, readerOpts []metadat
 	if !ok {
 		return nil
 	}
+	// prepend to allow to override the dialect
+	opts = append([]completer.Option{
+		completer.WithDialect(completer.LexerDialect(d.LexerName).Merge(d.Dialect)),
+	}, opts...)
 	if d.NewCompleter != nil {
 		return d.NewCompleter(db, opts...)
 	}
 	if d.NewMetadataReader == nil {
+		// complete only keywords of the dialect
+		return completer.NewDefaultCompleter(opts...)
+	}
+	opts = append([]completer.Option{
+		completer.WithReader(d.NewMetadataReader(db, completerReaderOpts(readerOpts)...)),
+		completer.WithDB(db),
+	}, opts...)
+	return completer.NewDefaultCompleter(opts...)
+}
+
+// NewCachingReader wraps creating a caching database introspector for a
+// driver, configured for use by the completer. Returns nil when the driver
+// does not support metadata readers.
+func NewCachingReader(ctx context.Context, u *dburl.URL, db DB, readerOpts []metadata.ReaderOption, opts ...metadata.CacheOption) *metadata.CachingReader {
+	d, ok := drivers[u.Driver]
+	if !ok || d.NewMetadataReader == nil {
 		return nil
 	}
+	return metadata.NewCachingReader(d.NewMetadataReader(db, completerReaderOpts(readerOpts)...), opts...)
+}
+
+// completerReaderOpts prepends the default completer reader options to opts.
+func completerReaderOpts(opts []metadata.ReaderOption) []metadata.ReaderOption {
 	// prepend to allow to override default options
-	readerOpts = append([]metadata.ReaderOption{
+	return append([]metadata.ReaderOption{
 		// this needs to be relatively low, since autocomplete is very interactive
 		metadata.WithTimeout(3 * time.Second),
 		metadata.WithLimit(1000),
-	}, readerOpts...)
-	opts = append([]completer.Option{
-		completer.WithReader(d.NewMetadataReader(db, readerOpts...)),
-		completer.WithDB(db),
 	}, opts...)
-	return completer.NewDefaultCompleter(opts...)
 }
 
 // Copy copies the result set to the destination sql.DB.
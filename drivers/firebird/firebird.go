@@ -24,5 +24,6 @@ func init() {
 			}
 			return "Firebird " + ver, nil
 		},
+		NewMetadataReader: NewMetadataReader,
 	})
 }
@@ -0,0 +1,403 @@
+package firebird
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/metadata"
+)
+
+type MetadataReader struct {
+	metadata.LoggingReader
+}
+
+// NewMetadataReader creates the metadata reader for Firebird databases.
+func NewMetadataReader(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
+	return &MetadataReader{
+		LoggingReader: metadata.NewLoggingReader(db, opts...),
+	}
+}
+
+var (
+	_ metadata.BasicReader            = &MetadataReader{}
+	_ metadata.IndexReader            = &MetadataReader{}
+	_ metadata.IndexColumnReader      = &MetadataReader{}
+	_ metadata.ConstraintReader       = &MetadataReader{}
+	_ metadata.ConstraintColumnReader = &MetadataReader{}
+	_ metadata.SequenceReader         = &MetadataReader{}
+)
+
+// Schemas returns no schemas, since Firebird databases do not have any.
+func (r MetadataReader) Schemas(metadata.Filter) (*metadata.SchemaSet, error) {
+	return metadata.NewSchemaSet([]metadata.Schema{}), nil
+}
+
+func (r MetadataReader) Tables(f metadata.Filter) (*metadata.TableSet, error) {
+	qstr := `SELECT
+  table_name,
+  table_type,
+  table_comment
+FROM (
+  SELECT
+    TRIM(RDB$RELATION_NAME) AS table_name,
+    CASE
+      WHEN RDB$SYSTEM_FLAG = 1 THEN 'SYSTEM TABLE'
+      WHEN RDB$VIEW_BLR IS NOT NULL THEN 'VIEW'
+      WHEN RDB$RELATION_TYPE IN (4, 5) THEN 'GLOBAL TEMPORARY'
+      ELSE 'TABLE'
+    END AS table_type,
+    COALESCE(CAST(RDB$DESCRIPTION AS VARCHAR(1024)), '') AS table_comment
+  FROM RDB$RELATIONS
+) t`
+	var conds []string
+	var vals []interface{}
+	if !f.WithSystem {
+		conds = append(conds, "table_type <> 'SYSTEM TABLE'")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "table_name LIKE ?")
+	}
+	if len(f.Types) != 0 {
+		var pholders []string
+		for _, t := range f.Types {
+			vals = append(vals, t)
+			pholders = append(pholders, "?")
+		}
+		conds = append(conds, "table_type IN ("+strings.Join(pholders, ", ")+")")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "table_type, table_name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Table
+	for rows.Next() {
+		var rec metadata.Table
+		if err := rows.Scan(&rec.Name, &rec.Type, &rec.Comment); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewTableSet(results), nil
+}
+
+func (r MetadataReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
+	qstr := `SELECT
+  TRIM(rf.RDB$RELATION_NAME),
+  TRIM(rf.RDB$FIELD_NAME),
+  rf.RDB$FIELD_POSITION + 1,
+  CASE
+    WHEN f.RDB$FIELD_TYPE IN (7, 8, 16) AND f.RDB$FIELD_SUB_TYPE = 1 THEN 'NUMERIC'
+    WHEN f.RDB$FIELD_TYPE IN (7, 8, 16) AND f.RDB$FIELD_SUB_TYPE = 2 THEN 'DECIMAL'
+    WHEN f.RDB$FIELD_TYPE = 7 THEN 'SMALLINT'
+    WHEN f.RDB$FIELD_TYPE = 8 THEN 'INTEGER'
+    WHEN f.RDB$FIELD_TYPE = 10 THEN 'FLOAT'
+    WHEN f.RDB$FIELD_TYPE = 12 THEN 'DATE'
+    WHEN f.RDB$FIELD_TYPE = 13 THEN 'TIME'
+    WHEN f.RDB$FIELD_TYPE = 14 THEN 'CHAR'
+    WHEN f.RDB$FIELD_TYPE = 16 THEN 'BIGINT'
+    WHEN f.RDB$FIELD_TYPE = 23 THEN 'BOOLEAN'
+    WHEN f.RDB$FIELD_TYPE = 27 THEN 'DOUBLE PRECISION'
+    WHEN f.RDB$FIELD_TYPE = 35 THEN 'TIMESTAMP'
+    WHEN f.RDB$FIELD_TYPE = 37 THEN 'VARCHAR'
+    WHEN f.RDB$FIELD_TYPE = 261 THEN 'BLOB'
+    ELSE 'UNKNOWN'
+  END,
+  COALESCE(f.RDB$CHARACTER_LENGTH, f.RDB$FIELD_PRECISION, 0),
+  COALESCE(-f.RDB$FIELD_SCALE, 0),
+  CASE WHEN COALESCE(rf.RDB$NULL_FLAG, f.RDB$NULL_FLAG, 0) = 1 THEN 'NO' ELSE 'YES' END,
+  COALESCE(CAST(COALESCE(rf.RDB$DEFAULT_SOURCE, f.RDB$DEFAULT_SOURCE) AS VARCHAR(1024)), '')
+FROM RDB$RELATION_FIELDS rf
+JOIN RDB$FIELDS f ON f.RDB$FIELD_NAME = rf.RDB$FIELD_SOURCE`
+	var conds []string
+	var vals []interface{}
+	if !f.WithSystem {
+		conds = append(conds, "COALESCE(rf.RDB$SYSTEM_FLAG, 0) = 0")
+	}
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, "TRIM(rf.RDB$RELATION_NAME) LIKE ?")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "TRIM(rf.RDB$FIELD_NAME) LIKE ?")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "rf.RDB$RELATION_NAME, rf.RDB$FIELD_POSITION", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Column
+	for rows.Next() {
+		rec := metadata.Column{NumPrecRadix: 10}
+		if err := rows.Scan(
+			&rec.Table,
+			&rec.Name,
+			&rec.OrdinalPosition,
+			&rec.DataType,
+			&rec.ColumnSize,
+			&rec.DecimalDigits,
+			&rec.IsNullable,
+			&rec.Default,
+		); err != nil {
+			return nil, err
+		}
+		// default sources include the keyword, ie DEFAULT 0
+		if def := strings.TrimSpace(rec.Default); strings.HasPrefix(strings.ToUpper(def), "DEFAULT ") {
+			rec.Default = strings.TrimSpace(def[len("DEFAULT "):])
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewColumnSet(results), nil
+}
+
+func (r MetadataReader) Indexes(f metadata.Filter) (*metadata.IndexSet, error) {
+	qstr := `SELECT
+  TRIM(i.RDB$RELATION_NAME),
+  TRIM(i.RDB$INDEX_NAME),
+  CASE WHEN c.RDB$CONSTRAINT_TYPE = 'PRIMARY KEY' THEN 'YES' ELSE 'NO' END,
+  CASE WHEN i.RDB$UNIQUE_FLAG = 1 THEN 'YES' ELSE 'NO' END,
+  CASE WHEN i.RDB$INDEX_TYPE = 1 THEN 'DESCENDING' ELSE 'ASCENDING' END
+FROM RDB$INDICES i
+LEFT JOIN RDB$RELATION_CONSTRAINTS c ON c.RDB$INDEX_NAME = i.RDB$INDEX_NAME`
+	conds, vals := indexConds(f)
+	rows, closeRows, err := r.query(qstr, conds, "i.RDB$RELATION_NAME, i.RDB$INDEX_NAME", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Index
+	for rows.Next() {
+		var rec metadata.Index
+		if err := rows.Scan(&rec.Table, &rec.Name, &rec.IsPrimary, &rec.IsUnique, &rec.Type); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewIndexSet(results), nil
+}
+
+func (r MetadataReader) IndexColumns(f metadata.Filter) (*metadata.IndexColumnSet, error) {
+	qstr := `SELECT
+  TRIM(i.RDB$RELATION_NAME),
+  TRIM(i.RDB$INDEX_NAME),
+  TRIM(s.RDB$FIELD_NAME),
+  s.RDB$FIELD_POSITION + 1
+FROM RDB$INDICES i
+JOIN RDB$INDEX_SEGMENTS s ON s.RDB$INDEX_NAME = i.RDB$INDEX_NAME`
+	conds, vals := indexConds(f)
+	rows, closeRows, err := r.query(qstr, conds, "i.RDB$RELATION_NAME, i.RDB$INDEX_NAME, s.RDB$FIELD_POSITION", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.IndexColumn
+	for rows.Next() {
+		var rec metadata.IndexColumn
+		if err := rows.Scan(&rec.Table, &rec.IndexName, &rec.Name, &rec.OrdinalPosition); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewIndexColumnSet(results), nil
+}
+
+// indexConds returns conditions of the filter for RDB$INDICES.
+func indexConds(f metadata.Filter) ([]string, []interface{}) {
+	var conds []string
+	var vals []interface{}
+	if !f.WithSystem {
+		conds = append(conds, "COALESCE(i.RDB$SYSTEM_FLAG, 0) = 0")
+	}
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, "TRIM(i.RDB$RELATION_NAME) LIKE ?")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "TRIM(i.RDB$INDEX_NAME) LIKE ?")
+	}
+	return conds, vals
+}
+
+func (r MetadataReader) Constraints(f metadata.Filter) (*metadata.ConstraintSet, error) {
+	qstr := `SELECT
+  TRIM(c.RDB$RELATION_NAME),
+  TRIM(c.RDB$CONSTRAINT_NAME),
+  TRIM(c.RDB$CONSTRAINT_TYPE),
+  TRIM(c.RDB$DEFERRABLE),
+  TRIM(c.RDB$INITIALLY_DEFERRED),
+  COALESCE(TRIM(u.RDB$RELATION_NAME), ''),
+  COALESCE(TRIM(u.RDB$CONSTRAINT_NAME), ''),
+  COALESCE(TRIM(ref.RDB$MATCH_OPTION), ''),
+  COALESCE(TRIM(ref.RDB$UPDATE_RULE), ''),
+  COALESCE(TRIM(ref.RDB$DELETE_RULE), ''),
+  COALESCE(CAST(t.RDB$TRIGGER_SOURCE AS VARCHAR(8191)), '')
+FROM RDB$RELATION_CONSTRAINTS c
+LEFT JOIN RDB$REF_CONSTRAINTS ref ON ref.RDB$CONSTRAINT_NAME = c.RDB$CONSTRAINT_NAME
+LEFT JOIN RDB$RELATION_CONSTRAINTS u ON u.RDB$CONSTRAINT_NAME = ref.RDB$CONST_NAME_UQ
+LEFT JOIN RDB$CHECK_CONSTRAINTS cc ON cc.RDB$CONSTRAINT_NAME = c.RDB$CONSTRAINT_NAME
+  AND c.RDB$CONSTRAINT_TYPE = 'CHECK'
+LEFT JOIN RDB$TRIGGERS t ON t.RDB$TRIGGER_NAME = cc.RDB$TRIGGER_NAME
+  AND t.RDB$TRIGGER_TYPE = 1`
+	conds, vals := constraintConds(f)
+	rows, closeRows, err := r.query(qstr, conds, "c.RDB$RELATION_NAME, c.RDB$CONSTRAINT_NAME", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Constraint
+	for rows.Next() {
+		var rec metadata.Constraint
+		if err := rows.Scan(
+			&rec.Table,
+			&rec.Name,
+			&rec.Type,
+			&rec.IsDeferrable,
+			&rec.IsInitiallyDeferred,
+			&rec.ForeignTable,
+			&rec.ForeignName,
+			&rec.MatchType,
+			&rec.UpdateRule,
+			&rec.DeleteRule,
+			&rec.CheckClause,
+		); err != nil {
+			return nil, err
+		}
+		// trigger sources of checks are the whole clause, ie CHECK (a > 0)
+		if clause := strings.TrimSpace(rec.CheckClause); strings.HasPrefix(strings.ToUpper(clause), "CHECK") {
+			rec.CheckClause = strings.TrimSpace(clause[len("CHECK"):])
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewConstraintSet(results), nil
+}
+
+func (r MetadataReader) ConstraintColumns(f metadata.Filter) (*metadata.ConstraintColumnSet, error) {
+	qstr := `SELECT
+  TRIM(c.RDB$RELATION_NAME),
+  TRIM(c.RDB$CONSTRAINT_NAME),
+  TRIM(s.RDB$FIELD_NAME),
+  s.RDB$FIELD_POSITION + 1,
+  COALESCE(TRIM(u.RDB$RELATION_NAME), ''),
+  COALESCE(TRIM(us.RDB$FIELD_NAME), '')
+FROM RDB$RELATION_CONSTRAINTS c
+JOIN RDB$INDEX_SEGMENTS s ON s.RDB$INDEX_NAME = c.RDB$INDEX_NAME
+LEFT JOIN RDB$REF_CONSTRAINTS ref ON ref.RDB$CONSTRAINT_NAME = c.RDB$CONSTRAINT_NAME
+LEFT JOIN RDB$RELATION_CONSTRAINTS u ON u.RDB$CONSTRAINT_NAME = ref.RDB$CONST_NAME_UQ
+LEFT JOIN RDB$INDEX_SEGMENTS us ON us.RDB$INDEX_NAME = u.RDB$INDEX_NAME
+  AND us.RDB$FIELD_POSITION = s.RDB$FIELD_POSITION`
+	conds, vals := constraintConds(f)
+	rows, closeRows, err := r.query(qstr, conds, "c.RDB$RELATION_NAME, c.RDB$CONSTRAINT_NAME, s.RDB$FIELD_POSITION", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.ConstraintColumn
+	for rows.Next() {
+		var rec metadata.ConstraintColumn
+		if err := rows.Scan(
+			&rec.Table,
+			&rec.Constraint,
+			&rec.Name,
+			&rec.OrdinalPosition,
+			&rec.ForeignTable,
+			&rec.ForeignName,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewConstraintColumnSet(results), nil
+}
+
+// constraintConds returns conditions of the filter for
+// RDB$RELATION_CONSTRAINTS, referencing unique constraints as u.
+func constraintConds(f metadata.Filter) ([]string, []interface{}) {
+	conds := []string{"c.RDB$CONSTRAINT_TYPE <> 'NOT NULL'"}
+	var vals []interface{}
+	if !f.WithSystem {
+		conds = append(conds, "c.RDB$RELATION_NAME NOT STARTING WITH 'RDB$'", "c.RDB$RELATION_NAME NOT STARTING WITH 'MON$'")
+	}
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, "TRIM(c.RDB$RELATION_NAME) LIKE ?")
+	}
+	if f.Reference != "" {
+		vals = append(vals, f.Reference)
+		conds = append(conds, "TRIM(u.RDB$RELATION_NAME) LIKE ?")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "TRIM(c.RDB$CONSTRAINT_NAME) LIKE ?")
+	}
+	return conds, vals
+}
+
+func (r MetadataReader) Sequences(f metadata.Filter) (*metadata.SequenceSet, error) {
+	qstr := `SELECT
+  TRIM(RDB$GENERATOR_NAME),
+  'BIGINT',
+  CAST(RDB$INITIAL_VALUE AS VARCHAR(32)),
+  '-9223372036854775808',
+  '9223372036854775807',
+  CAST(RDB$GENERATOR_INCREMENT AS VARCHAR(32)),
+  'NO'
+FROM RDB$GENERATORS`
+	var conds []string
+	var vals []interface{}
+	if !f.WithSystem {
+		conds = append(conds, "COALESCE(RDB$SYSTEM_FLAG, 0) = 0")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "TRIM(RDB$GENERATOR_NAME) LIKE ?")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "RDB$GENERATOR_NAME", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Sequence
+	for rows.Next() {
+		var rec metadata.Sequence
+		if err := rows.Scan(&rec.Name, &rec.DataType, &rec.Start, &rec.Min, &rec.Max, &rec.Increment, &rec.Cycles); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewSequenceSet(results), nil
+}
+
+func (r MetadataReader) query(qstr string, conds []string, order string, vals ...interface{}) (*sql.Rows, func(), error) {
+	if len(conds) != 0 {
+		qstr += "\nWHERE " + strings.Join(conds, " AND ")
+	}
+	if order != "" {
+		qstr += "\nORDER BY " + order
+	}
+	return r.Query(qstr, vals...)
+}
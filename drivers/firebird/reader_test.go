@@ -0,0 +1,139 @@
+package firebird
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/drivers/metadata/metadatatest"
+)
+
+func newReader(t *testing.T) (*MetadataReader, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock := metadatatest.NewDB(t, nil)
+	return NewMetadataReader(db).(*MetadataReader), mock
+}
+
+func TestTables(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM RDB\$RELATIONS\s+\) t\s+WHERE table_type <> 'SYSTEM TABLE' AND table_name LIKE \?`).
+		WithArgs("F%").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "table_type", "table_comment"}).
+			AddRow("FILM", "TABLE", "films").
+			AddRow("FILM_LIST", "VIEW", ""))
+	res, err := r.Tables(metadata.Filter{Name: "F%"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		names = append(names, res.Get().Name+":"+res.Get().Type+":"+res.Get().Comment)
+	}
+	if s, exp := strings.Join(names, ", "), "FILM:TABLE:films, FILM_LIST:VIEW:"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestTableFilters(t *testing.T) {
+	tests := []struct {
+		filter metadata.Filter
+		query  string
+		args   []driver.Value
+	}{
+		{metadata.Filter{}, `\) t\s+WHERE table_type <> 'SYSTEM TABLE'\s+ORDER BY`, nil},
+		{metadata.Filter{WithSystem: true}, `\) t\s+ORDER BY`, nil},
+		{metadata.Filter{Types: []string{"VIEW", "GLOBAL TEMPORARY"}}, `\) t\s+WHERE table_type <> 'SYSTEM TABLE' AND table_type IN \(\?, \?\)\s+ORDER BY`, []driver.Value{"VIEW", "GLOBAL TEMPORARY"}},
+	}
+	for i, test := range tests {
+		r, mock := newReader(t)
+		mock.ExpectQuery(test.query).
+			WithArgs(test.args...).
+			WillReturnRows(sqlmock.NewRows([]string{"table_name", "table_type", "table_comment"}))
+		if _, err := r.Tables(test.filter); err != nil {
+			t.Errorf("test %d expected no error, got: %v", i, err)
+		}
+	}
+}
+
+func TestColumnFilters(t *testing.T) {
+	tests := []struct {
+		filter metadata.Filter
+		query  string
+	}{
+		{metadata.Filter{}, `WHERE COALESCE\(rf\.RDB\$SYSTEM_FLAG, 0\) = 0\s+ORDER BY`},
+		{metadata.Filter{WithSystem: true}, `RDB\$FIELD_SOURCE\s+ORDER BY`},
+	}
+	for i, test := range tests {
+		r, mock := newReader(t)
+		mock.ExpectQuery(test.query).
+			WillReturnRows(sqlmock.NewRows([]string{"table", "name", "pos", "type", "size", "scale", "nullable", "default"}))
+		if _, err := r.Columns(test.filter); err != nil {
+			t.Errorf("test %d expected no error, got: %v", i, err)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM RDB\$RELATION_FIELDS rf`).
+		WithArgs("FILM").
+		WillReturnRows(sqlmock.NewRows([]string{"table", "name", "pos", "type", "size", "scale", "nullable", "default"}).
+			AddRow("FILM", "FILM_ID", 1, "INTEGER", 0, 0, "NO", "").
+			AddRow("FILM", "LENGTH", 2, "SMALLINT", 0, 0, "YES", "DEFAULT 90").
+			AddRow("FILM", "RENTAL_RATE", 3, "NUMERIC", 4, 2, "NO", "  default 4.99"))
+	res, err := r.Columns(metadata.Filter{Parent: "FILM"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var cols []string
+	for res.Next() {
+		c := res.Get()
+		cols = append(cols, strings.Join([]string{c.Name, c.DataType, string(c.IsNullable), c.Default}, " "))
+	}
+	exp := "FILM_ID INTEGER NO , LENGTH SMALLINT YES 90, RENTAL_RATE NUMERIC NO 4.99"
+	if s := strings.Join(cols, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM RDB\$RELATION_CONSTRAINTS c.*TRIM\(u\.RDB\$RELATION_NAME\) LIKE \?`).
+		WithArgs("FILM").
+		WillReturnRows(sqlmock.NewRows([]string{"table", "name", "type", "deferrable", "deferred", "ftable", "fname", "match", "update", "delete", "check"}).
+			AddRow("FILM_ACTOR", "FK_FILM", "FOREIGN KEY", "NO", "NO", "FILM", "PK_FILM", "FULL", "RESTRICT", "CASCADE", "").
+			AddRow("FILM_ACTOR", "CK_ORDER", "CHECK", "NO", "NO", "", "", "", "", "", "CHECK (ordering > 0)"))
+	res, err := r.Constraints(metadata.Filter{Reference: "FILM"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		c := res.Get()
+		names = append(names, strings.Join([]string{c.Name, c.Type, c.ForeignTable, c.DeleteRule, c.CheckClause}, ":"))
+	}
+	exp := "FK_FILM:FOREIGN KEY:FILM:CASCADE:, CK_ORDER:CHECK:::(ordering > 0)"
+	if s := strings.Join(names, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestSequences(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM RDB\$GENERATORS\s+WHERE COALESCE\(RDB\$SYSTEM_FLAG, 0\) = 0\s+ORDER BY`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "start", "min", "max", "increment", "cycles"}).
+			AddRow("FILM_SEQ", "BIGINT", "1", "-9223372036854775808", "9223372036854775807", "1", "NO"))
+	res, err := r.Sequences(metadata.Filter{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		names = append(names, res.Get().Name+":"+res.Get().Start+":"+res.Get().Increment)
+	}
+	if s, exp := strings.Join(names, ", "), "FILM_SEQ:1:1"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
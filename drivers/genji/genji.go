@@ -9,5 +9,9 @@ import (
 )
 
 func init() {
-	drivers.Register("genji", drivers.Driver{})
+	drivers.Register("genji", drivers.Driver{
+		AllowBackslashEscapes: true,
+		AllowBackticks:        true,
+		NewMetadataReader:     NewMetadataReader,
+	})
 }
@@ -0,0 +1,312 @@
+package genji
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/metadata"
+)
+
+type MetadataReader struct {
+	metadata.LoggingReader
+}
+
+// NewMetadataReader creates the metadata reader for genji databases.
+func NewMetadataReader(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
+	return &MetadataReader{
+		LoggingReader: metadata.NewLoggingReader(db, opts...),
+	}
+}
+
+var (
+	_ metadata.BasicReader       = &MetadataReader{}
+	_ metadata.IndexReader       = &MetadataReader{}
+	_ metadata.IndexColumnReader = &MetadataReader{}
+)
+
+// catalogEntry is a table or index in __genji_catalog.
+type catalogEntry struct {
+	name  string
+	sql   string
+	table string
+}
+
+// Schemas returns no schemas, since genji databases do not have any.
+func (r MetadataReader) Schemas(metadata.Filter) (*metadata.SchemaSet, error) {
+	return metadata.NewSchemaSet([]metadata.Schema{}), nil
+}
+
+func (r MetadataReader) Tables(f metadata.Filter) (*metadata.TableSet, error) {
+	entries, err := r.catalog("table", f.Name, "", f.WithSystem)
+	if err != nil {
+		return nil, err
+	}
+	var results []metadata.Table
+	for _, e := range entries {
+		rec := metadata.Table{Name: e.name, Type: "TABLE"}
+		if isSystem(e.name) {
+			rec.Type = "SYSTEM TABLE"
+		}
+		if !metadata.HasType(f.Types, rec.Type) {
+			continue
+		}
+		results = append(results, rec)
+	}
+	return metadata.NewTableSet(results), nil
+}
+
+// Columns parses field constraints of table definitions, since genji does
+// not keep track of columns separately; tables without constraints have no
+// columns.
+func (r MetadataReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
+	entries, err := r.catalog("table", f.Parent, "", true)
+	if err != nil {
+		return nil, err
+	}
+	match := metadata.LikeMatcher(f.Name)
+	var results []metadata.Column
+	for _, e := range entries {
+		pos := 0
+		for _, def := range parenDefs(e.sql) {
+			if def == "..." || strings.HasPrefix(def, "CONSTRAINT ") {
+				continue
+			}
+			pos++
+			rec := parseField(def)
+			if !match(rec.Name) {
+				continue
+			}
+			rec.Table, rec.OrdinalPosition = e.name, pos
+			results = append(results, rec)
+		}
+	}
+	return metadata.NewColumnSet(results), nil
+}
+
+// Indexes returns indexes of the catalog, and primary keys of table
+// definitions.
+func (r MetadataReader) Indexes(f metadata.Filter) (*metadata.IndexSet, error) {
+	indexes, err := r.indexes(f)
+	if err != nil {
+		return nil, err
+	}
+	var results []metadata.Index
+	for _, i := range indexes {
+		results = append(results, i.Index)
+	}
+	return metadata.NewIndexSet(results), nil
+}
+
+func (r MetadataReader) IndexColumns(f metadata.Filter) (*metadata.IndexColumnSet, error) {
+	indexes, err := r.indexes(f)
+	if err != nil {
+		return nil, err
+	}
+	var results []metadata.IndexColumn
+	for _, i := range indexes {
+		for n, col := range i.columns {
+			results = append(results, metadata.IndexColumn{
+				Table:           i.Table,
+				IndexName:       i.Name,
+				Name:            col,
+				OrdinalPosition: n + 1,
+			})
+		}
+	}
+	return metadata.NewIndexColumnSet(results), nil
+}
+
+// index is an index with its columns.
+type index struct {
+	metadata.Index
+	columns []string
+}
+
+// indexes returns indexes of tables matching the parent of the filter,
+// sorted by table and name.
+func (r MetadataReader) indexes(f metadata.Filter) ([]index, error) {
+	tables, err := r.catalog("table", f.Parent, "", f.WithSystem)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := r.catalog("index", f.Name, f.Parent, f.WithSystem)
+	if err != nil {
+		return nil, err
+	}
+	match := metadata.LikeMatcher(f.Name)
+	byTable := make(map[string][]catalogEntry)
+	for _, e := range entries {
+		byTable[e.table] = append(byTable[e.table], e)
+	}
+	var results []index
+	for _, t := range tables {
+		for _, def := range parenDefs(t.sql) {
+			// CONSTRAINT name PRIMARY KEY (paths)
+			fields := strings.Fields(def)
+			if len(fields) < 4 || fields[0] != "CONSTRAINT" || fields[2] != "PRIMARY" {
+				continue
+			}
+			name := unquote(fields[1])
+			if !match(name) {
+				continue
+			}
+			results = append(results, index{
+				Index: metadata.Index{
+					Table:     t.name,
+					Name:      name,
+					IsPrimary: metadata.YES,
+					IsUnique:  metadata.YES,
+				},
+				columns: unquoteAll(parenDefs(def)),
+			})
+		}
+		for _, e := range byTable[t.name] {
+			i := index{
+				Index: metadata.Index{
+					Table:     t.name,
+					Name:      e.name,
+					IsPrimary: metadata.NO,
+					IsUnique:  metadata.NO,
+				},
+				columns: unquoteAll(parenDefs(e.sql)),
+			}
+			if strings.HasPrefix(e.sql, "CREATE UNIQUE ") {
+				i.IsUnique = metadata.YES
+			}
+			results = append(results, i)
+		}
+	}
+	return results, nil
+}
+
+// catalog returns entries of the type in __genji_catalog, with names and
+// owner tables matching patterns, sorted by name.
+func (r MetadataReader) catalog(typ, name, table string, withSystem bool) ([]catalogEntry, error) {
+	qstr := `SELECT
+  name,
+  sql,
+  owner.table_name
+FROM __genji_catalog`
+	conds := []string{"type = ?"}
+	vals := []interface{}{typ}
+	if name != "" {
+		vals = append(vals, name)
+		conds = append(conds, "name LIKE ?")
+	}
+	if table != "" {
+		vals = append(vals, table)
+		conds = append(conds, "owner.table_name LIKE ?")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []catalogEntry
+	for rows.Next() {
+		var rec catalogEntry
+		var owner sql.NullString
+		if err := rows.Scan(&rec.name, &rec.sql, &owner); err != nil {
+			return nil, err
+		}
+		rec.table = owner.String
+		if !withSystem && (isSystem(rec.name) || isSystem(rec.table)) {
+			continue
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return results, nil
+}
+
+func (r MetadataReader) query(qstr string, conds []string, order string, vals ...interface{}) (*sql.Rows, func(), error) {
+	if len(conds) != 0 {
+		qstr += "\nWHERE " + strings.Join(conds, " AND ")
+	}
+	if order != "" {
+		qstr += "\nORDER BY " + order
+	}
+	return r.Query(qstr, vals...)
+}
+
+// parenDefs returns the comma separated definitions in the first
+// parenthesized list of a statement.
+func parenDefs(stmt string) []string {
+	var defs []string
+	var quote rune
+	depth, start := 0, 0
+	for i, c := range stmt {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '`' || c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			if depth++; depth == 1 {
+				start = i + 1
+			}
+		case c == ',' && depth == 1:
+			defs, start = append(defs, strings.TrimSpace(stmt[start:i])), i+1
+		case c == ')' && depth != 0:
+			if depth--; depth == 0 {
+				return append(defs, strings.TrimSpace(stmt[start:i]))
+			}
+		}
+	}
+	return defs
+}
+
+// parseField parses a field constraint of a table definition, ie
+// a INTEGER NOT NULL DEFAULT 1.
+func parseField(def string) metadata.Column {
+	col := metadata.Column{IsNullable: metadata.YES}
+	end := strings.IndexAny(def, " (")
+	if strings.HasPrefix(def, "`") {
+		end = strings.Index(def[1:], "`") + 2
+	}
+	if end <= 0 {
+		end = len(def)
+	}
+	col.Name, def = unquote(def[:end]), strings.TrimSpace(def[end:])
+	if i := strings.Index(" "+def, " DEFAULT "); i != -1 {
+		col.Default, def = strings.TrimSpace(def[i+len("DEFAULT "):]), def[:i]
+	}
+	def = strings.TrimSpace(def)
+	if strings.HasSuffix(def, "NOT NULL") {
+		col.IsNullable, def = metadata.NO, strings.TrimSuffix(def, "NOT NULL")
+	}
+	col.DataType = strings.TrimSpace(def)
+	switch {
+	case col.DataType == "":
+		col.DataType = "ANY"
+	case strings.HasPrefix(col.DataType, "("):
+		col.DataType = "DOCUMENT"
+	}
+	return col
+}
+
+// unquote returns the unquoted identifier.
+func unquote(s string) string {
+	if len(s) > 1 && (s[0] == '`' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// unquoteAll returns the unquoted identifiers.
+func unquoteAll(v []string) []string {
+	for i, s := range v {
+		v[i] = unquote(s)
+	}
+	return v
+}
+
+// isSystem returns true when name is a genji system table.
+func isSystem(name string) bool {
+	return strings.HasPrefix(name, "__genji_")
+}
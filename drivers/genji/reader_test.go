@@ -0,0 +1,117 @@
+package genji
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/xo/usql/drivers/metadata"
+)
+
+func newReader(t *testing.T) *MetadataReader {
+	t.Helper()
+	db, err := sql.Open("genji", ":memory:")
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	for _, q := range []string{
+		`CREATE TABLE film (id INTEGER PRIMARY KEY, title TEXT NOT NULL, length INTEGER DEFAULT 90, rating DOUBLE, info (lang TEXT, ...), UNIQUE (title))`,
+		`CREATE TABLE actor`,
+		`CREATE INDEX film_length_rating ON film (length, rating)`,
+		`CREATE SEQUENCE film_seq`,
+	} {
+		if _, err := db.Exec(q); err != nil {
+			t.Fatalf("could not execute %q: %v", q, err)
+		}
+	}
+	return NewMetadataReader(db).(*MetadataReader)
+}
+
+func TestTables(t *testing.T) {
+	r := newReader(t)
+	tests := []struct {
+		filter metadata.Filter
+		exp    string
+	}{
+		{metadata.Filter{}, "actor:TABLE, film:TABLE"},
+		{metadata.Filter{Name: "f%"}, "film:TABLE"},
+		{metadata.Filter{Name: "__genji_cat%", WithSystem: true}, "__genji_catalog:SYSTEM TABLE"},
+		{metadata.Filter{Types: []string{"VIEW"}}, ""},
+		{metadata.Filter{Types: []string{"TABLE"}, WithSystem: true}, "actor:TABLE, film:TABLE"},
+	}
+	for i, test := range tests {
+		res, err := r.Tables(test.filter)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		var names []string
+		for res.Next() {
+			names = append(names, res.Get().Name+":"+res.Get().Type)
+		}
+		if s := strings.Join(names, ", "); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	r := newReader(t)
+	tests := []struct {
+		filter metadata.Filter
+		exp    string
+	}{
+		{metadata.Filter{Parent: "film"}, "1 id INTEGER NO , 2 title TEXT NO , 3 length INTEGER YES 90, 4 rating DOUBLE YES , 5 info DOCUMENT YES "},
+		{metadata.Filter{Parent: "film", Name: "r%"}, "4 rating DOUBLE YES "},
+		{metadata.Filter{Parent: "actor"}, ""},
+	}
+	for i, test := range tests {
+		res, err := r.Columns(test.filter)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		var cols []string
+		for res.Next() {
+			c := res.Get()
+			cols = append(cols, strings.Join([]string{strconv.Itoa(c.OrdinalPosition), c.Name, c.DataType, string(c.IsNullable), c.Default}, " "))
+		}
+		if s := strings.Join(cols, ", "); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	r := newReader(t)
+	res, err := r.Indexes(metadata.Filter{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		i := res.Get()
+		names = append(names, i.Table+"."+i.Name+":"+string(i.IsPrimary)+":"+string(i.IsUnique))
+	}
+	exp := "film.film_pk:YES:YES, film.film_length_rating:NO:NO, film.film_title_idx:NO:YES"
+	if s := strings.Join(names, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestIndexColumns(t *testing.T) {
+	r := newReader(t)
+	res, err := r.IndexColumns(metadata.Filter{Parent: "film", Name: "film_%"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		c := res.Get()
+		names = append(names, c.IndexName+"."+c.Name+":"+strconv.Itoa(c.OrdinalPosition))
+	}
+	exp := "film_pk.id:1, film_length_rating.length:1, film_length_rating.rating:2, film_title_idx.title:1"
+	if s := strings.Join(names, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
@@ -13,5 +13,6 @@ func init() {
 		AllowDollar:            true,
 		AllowMultilineComments: true,
 		AllowCComments:         true,
+		NewMetadataReader:      NewMetadataReader,
 	})
 }
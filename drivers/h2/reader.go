@@ -0,0 +1,231 @@
+package h2
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/metadata"
+	infos "github.com/xo/usql/drivers/metadata/informationschema"
+)
+
+type metaReader struct {
+	metadata.LoggingReader
+}
+
+var (
+	_ metadata.IndexReader       = &metaReader{}
+	_ metadata.IndexColumnReader = &metaReader{}
+	_ metadata.SequenceReader    = &metaReader{}
+)
+
+// NewMetadataReader creates the metadata reader for H2 databases, reading
+// indexes and sequences from H2 specific tables of its information schema.
+func NewMetadataReader(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
+	newIS := infos.New(
+		infos.WithPlaceholder(func(int) string { return "?" }),
+		infos.WithCustomClauses(map[infos.ClauseName]string{
+			// data_type is a JDBC type code
+			infos.ColumnsDataType:   "type_name",
+			infos.ColumnsColumnSize: "COALESCE(character_maximum_length, numeric_precision, 0)",
+		}),
+		infos.WithFunctions(false),
+		infos.WithIndexes(false),
+		infos.WithSequences(false),
+		infos.WithTablePrivileges(false),
+		infos.WithColumnPrivileges(false),
+		infos.WithUsagePrivileges(false),
+		infos.WithSystemSchemas([]string{"INFORMATION_SCHEMA"}),
+		infos.WithCurrentSchema("SCHEMA()"),
+	)
+	return metadata.NewPluginReader(
+		newIS(db, opts...),
+		&metaReader{
+			LoggingReader: metadata.NewLoggingReader(db, opts...),
+		},
+	)
+}
+
+func (r metaReader) Indexes(f metadata.Filter) (*metadata.IndexSet, error) {
+	qstr := `SELECT DISTINCT
+  table_catalog,
+  table_schema,
+  table_name,
+  index_name,
+  CASE WHEN primary_key THEN 'YES' ELSE 'NO' END,
+  CASE WHEN non_unique THEN 'NO' ELSE 'YES' END,
+  index_type_name
+FROM information_schema.indexes`
+	conds, vals := indexConds(f)
+	rows, closeRows, err := r.query(qstr, conds, "table_catalog, table_schema, table_name, index_name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Index
+	for rows.Next() {
+		var rec metadata.Index
+		if err := rows.Scan(
+			&rec.Catalog,
+			&rec.Schema,
+			&rec.Table,
+			&rec.Name,
+			&rec.IsPrimary,
+			&rec.IsUnique,
+			&rec.Type,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewIndexSet(results), nil
+}
+
+func (r metaReader) IndexColumns(f metadata.Filter) (*metadata.IndexColumnSet, error) {
+	qstr := `SELECT
+  i.table_catalog,
+  i.table_schema,
+  i.table_name,
+  i.index_name,
+  i.column_name,
+  c.type_name,
+  i.ordinal_position
+FROM information_schema.indexes i
+JOIN information_schema.columns c ON c.table_catalog = i.table_catalog
+  AND c.table_schema = i.table_schema
+  AND c.table_name = i.table_name
+  AND c.column_name = i.column_name`
+	conds, vals := indexConds(f)
+	for i, cond := range conds {
+		conds[i] = "i." + cond
+	}
+	rows, closeRows, err := r.query(qstr, conds, "i.table_catalog, i.table_schema, i.table_name, i.index_name, i.ordinal_position", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.IndexColumn
+	for rows.Next() {
+		var rec metadata.IndexColumn
+		if err := rows.Scan(
+			&rec.Catalog,
+			&rec.Schema,
+			&rec.Table,
+			&rec.IndexName,
+			&rec.Name,
+			&rec.DataType,
+			&rec.OrdinalPosition,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewIndexColumnSet(results), nil
+}
+
+// indexConds returns conditions of the filter for information_schema.indexes.
+func indexConds(f metadata.Filter) ([]string, []interface{}) {
+	var conds []string
+	var vals []interface{}
+	if f.Catalog != "" {
+		vals = append(vals, f.Catalog)
+		conds = append(conds, "table_catalog LIKE ?")
+	}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, "table_schema LIKE ?")
+	}
+	if !f.WithSystem {
+		conds = append(conds, "table_schema <> 'INFORMATION_SCHEMA'")
+	}
+	if f.OnlyVisible {
+		conds = append(conds, "table_schema = SCHEMA()")
+	}
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, "table_name LIKE ?")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "index_name LIKE ?")
+	}
+	return conds, vals
+}
+
+func (r metaReader) Sequences(f metadata.Filter) (*metadata.SequenceSet, error) {
+	// sequences do not have a data type nor a start value before H2 2.0
+	qstr := `SELECT
+  sequence_catalog,
+  sequence_schema,
+  sequence_name,
+  'BIGINT',
+  '',
+  CAST(min_value AS VARCHAR),
+  CAST(max_value AS VARCHAR),
+  CAST(increment AS VARCHAR),
+  CASE WHEN is_cycle THEN 'YES' ELSE 'NO' END
+FROM information_schema.sequences`
+	var conds []string
+	var vals []interface{}
+	if f.Catalog != "" {
+		vals = append(vals, f.Catalog)
+		conds = append(conds, "sequence_catalog LIKE ?")
+	}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, "sequence_schema LIKE ?")
+	}
+	if !f.WithSystem {
+		conds = append(conds, "sequence_schema <> 'INFORMATION_SCHEMA'")
+	}
+	if f.OnlyVisible {
+		conds = append(conds, "sequence_schema = SCHEMA()")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "sequence_name LIKE ?")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "sequence_catalog, sequence_schema, sequence_name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Sequence
+	for rows.Next() {
+		var rec metadata.Sequence
+		if err := rows.Scan(
+			&rec.Catalog,
+			&rec.Schema,
+			&rec.Name,
+			&rec.DataType,
+			&rec.Start,
+			&rec.Min,
+			&rec.Max,
+			&rec.Increment,
+			&rec.Cycles,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewSequenceSet(results), nil
+}
+
+func (r metaReader) query(qstr string, conds []string, order string, vals ...interface{}) (*sql.Rows, func(), error) {
+	if len(conds) != 0 {
+		qstr += "\nWHERE " + strings.Join(conds, " AND ")
+	}
+	if order != "" {
+		qstr += "\nORDER BY " + order
+	}
+	return r.Query(qstr, vals...)
+}
@@ -0,0 +1,128 @@
+package h2
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/drivers/metadata/metadatatest"
+)
+
+func newReader(t *testing.T) (metadata.Reader, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock := metadatatest.NewDB(t, nil)
+	return NewMetadataReader(db), mock
+}
+
+func TestColumns(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`type_name,.*FROM information_schema\.columns\s+WHERE table_schema NOT IN \(\?\) AND table_name LIKE \?`).
+		WithArgs("INFORMATION_SCHEMA", "FILM").
+		WillReturnRows(sqlmock.NewRows([]string{"catalog", "schema", "table", "name", "pos", "type", "default", "nullable", "size", "scale", "radix", "octet"}).
+			AddRow("TEST", "PUBLIC", "FILM", "ID", 1, "INTEGER", "", "NO", 10, 0, 10, 0).
+			AddRow("TEST", "PUBLIC", "FILM", "TITLE", 2, "VARCHAR", "", "YES", 255, 0, 10, 255))
+	res, err := r.(metadata.ColumnReader).Columns(metadata.Filter{Parent: "FILM"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var cols []string
+	for res.Next() {
+		c := res.Get()
+		cols = append(cols, c.Name+" "+c.DataType+" "+string(c.IsNullable))
+	}
+	if s, exp := strings.Join(cols, ", "), "ID INTEGER NO, TITLE VARCHAR YES"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`SELECT DISTINCT.*FROM information_schema\.indexes\s+WHERE table_schema <> 'INFORMATION_SCHEMA' AND table_name LIKE \?`).
+		WithArgs("FILM").
+		WillReturnRows(sqlmock.NewRows([]string{"catalog", "schema", "table", "name", "primary", "unique", "type"}).
+			AddRow("TEST", "PUBLIC", "FILM", "PRIMARY_KEY_2", "YES", "YES", "PRIMARY KEY").
+			AddRow("TEST", "PUBLIC", "FILM", "FILM_TITLE", "NO", "NO", "INDEX"))
+	res, err := r.(metadata.IndexReader).Indexes(metadata.Filter{Parent: "FILM"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		i := res.Get()
+		names = append(names, i.Name+":"+string(i.IsPrimary)+":"+string(i.IsUnique))
+	}
+	if s, exp := strings.Join(names, ", "), "PRIMARY_KEY_2:YES:YES, FILM_TITLE:NO:NO"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestSequences(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM information_schema\.sequences\s+WHERE sequence_schema <> 'INFORMATION_SCHEMA' AND sequence_name LIKE \?`).
+		WithArgs("FILM%").
+		WillReturnRows(sqlmock.NewRows([]string{"catalog", "schema", "name", "type", "start", "min", "max", "increment", "cycles"}).
+			AddRow("TEST", "PUBLIC", "FILM_SEQ", "BIGINT", "", "1", "9223372036854775807", "1", "NO"))
+	res, err := r.(metadata.SequenceReader).Sequences(metadata.Filter{Name: "FILM%"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		s := res.Get()
+		names = append(names, s.Schema+"."+s.Name+":"+s.Min+":"+string(s.Cycles))
+	}
+	if s, exp := strings.Join(names, ", "), "PUBLIC.FILM_SEQ:1:NO"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		filter metadata.Filter
+		index  string
+		seq    string
+		args   []driver.Value
+	}{
+		{
+			metadata.Filter{},
+			`FROM information_schema\.indexes\s+WHERE table_schema <> 'INFORMATION_SCHEMA'\s+ORDER BY`,
+			`FROM information_schema\.sequences\s+WHERE sequence_schema <> 'INFORMATION_SCHEMA'\s+ORDER BY`,
+			nil,
+		},
+		{
+			metadata.Filter{WithSystem: true},
+			`FROM information_schema\.indexes\s+ORDER BY`,
+			`FROM information_schema\.sequences\s+ORDER BY`,
+			nil,
+		},
+		{
+			metadata.Filter{OnlyVisible: true},
+			`FROM information_schema\.indexes\s+WHERE table_schema <> 'INFORMATION_SCHEMA' AND table_schema = SCHEMA\(\)\s+ORDER BY`,
+			`FROM information_schema\.sequences\s+WHERE sequence_schema <> 'INFORMATION_SCHEMA' AND sequence_schema = SCHEMA\(\)\s+ORDER BY`,
+			nil,
+		},
+		{
+			metadata.Filter{Schema: "INFORMATION_SCHEMA", WithSystem: true},
+			`FROM information_schema\.indexes\s+WHERE table_schema LIKE \?\s+ORDER BY`,
+			`FROM information_schema\.sequences\s+WHERE sequence_schema LIKE \?\s+ORDER BY`,
+			[]driver.Value{"INFORMATION_SCHEMA"},
+		},
+	}
+	for i, test := range tests {
+		r, mock := newReader(t)
+		mock.ExpectQuery(test.index).
+			WithArgs(test.args...).
+			WillReturnRows(sqlmock.NewRows([]string{"catalog", "schema", "table", "name", "primary", "unique", "type"}))
+		mock.ExpectQuery(test.seq).
+			WithArgs(test.args...).
+			WillReturnRows(sqlmock.NewRows([]string{"catalog", "schema", "name", "type", "start", "min", "max", "increment", "cycles"}))
+		if _, err := r.(metadata.IndexReader).Indexes(test.filter); err != nil {
+			t.Errorf("test %d expected no error, got: %v", i, err)
+		}
+		if _, err := r.(metadata.SequenceReader).Sequences(test.filter); err != nil {
+			t.Errorf("test %d expected no error, got: %v", i, err)
+		}
+	}
+}
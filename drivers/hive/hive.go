@@ -10,5 +10,8 @@ import (
 )
 
 func init() {
-	drivers.Register("hive", drivers.Driver{})
+	drivers.Register("hive", drivers.Driver{
+		AllowBackslashEscapes: true,
+		AllowBackticks:        true,
+	})
 }
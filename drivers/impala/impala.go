@@ -10,5 +10,8 @@ import (
 )
 
 func init() {
-	drivers.Register("impala", drivers.Driver{})
+	drivers.Register("impala", drivers.Driver{
+		AllowBackslashEscapes: true,
+		AllowBackticks:        true,
+	})
 }
@@ -9,5 +9,8 @@ import (
 )
 
 func init() {
-	drivers.Register("maxcompute", drivers.Driver{})
+	drivers.Register("maxcompute", drivers.Driver{
+		AllowBackslashEscapes: true,
+		AllowBackticks:        true,
+	})
 }
@@ -0,0 +1,366 @@
+package metadata
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CachingReader memoises results of another reader, per method and filter,
+// until they expire or the cache is invalidated.
+type CachingReader struct {
+	r       ExtendedReader
+	ttl     time.Duration
+	size    int
+	mu      sync.Mutex
+	gen     int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+var _ ExtendedReader = &CachingReader{}
+
+// NewCachingReader wraps a reader, caching results of all its methods.
+func NewCachingReader(r Reader, opts ...CacheOption) *CachingReader {
+	c := &CachingReader{
+		r:       NewPluginReader(r).(ExtendedReader),
+		ttl:     5 * time.Minute,
+		size:    256,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// CacheOption to configure the CachingReader
+type CacheOption func(*CachingReader)
+
+// WithCacheTTL after which cached results expire
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(c *CachingReader) {
+		c.ttl = ttl
+	}
+}
+
+// WithCacheSize limiting the number of cached results
+func WithCacheSize(size int) CacheOption {
+	return func(c *CachingReader) {
+		c.size = size
+	}
+}
+
+// Invalidate drops all cached results.
+func (c *CachingReader) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
+
+// Warm populates the cache with catalogs, and schemas, tables, functions
+// and sequences matching each of the filters. Errors are ignored.
+func (c *CachingReader) Warm(filters ...Filter) {
+	_, _ = c.Catalogs(Filter{})
+	for _, f := range filters {
+		_, _ = c.Schemas(f)
+		_, _ = c.Tables(f)
+		_, _ = c.Functions(f)
+		_, _ = c.Sequences(f)
+	}
+}
+
+// get returns a cached result for method and filter, or runs query and
+// caches its result, evicting the least recently used results over size.
+func (c *CachingReader) get(method string, f Filter, query func() (interface{}, error)) (interface{}, error) {
+	key := method + fmt.Sprintf("%#v", f)
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		entry := e.Value.(*cacheEntry)
+		if c.ttl <= 0 || time.Now().Before(entry.expires) {
+			c.order.MoveToFront(e)
+			c.mu.Unlock()
+			return entry.value, nil
+		}
+		c.order.Remove(e)
+		delete(c.entries, key)
+	}
+	gen := c.gen
+	c.mu.Unlock()
+	v, err := query()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// skip results read before the cache was invalidated
+	if gen != c.gen || c.size <= 0 {
+		return v, nil
+	}
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{
+		key:     key,
+		value:   v,
+		expires: time.Now().Add(c.ttl),
+	})
+	for c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*cacheEntry).key)
+	}
+	return v, nil
+}
+
+func (c *CachingReader) Catalogs(f Filter) (*CatalogSet, error) {
+	v, err := c.get("Catalogs", f, func() (interface{}, error) { return c.r.Catalogs(f) })
+	res, ok := v.(*CatalogSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Schemas(f Filter) (*SchemaSet, error) {
+	v, err := c.get("Schemas", f, func() (interface{}, error) { return c.r.Schemas(f) })
+	res, ok := v.(*SchemaSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Tables(f Filter) (*TableSet, error) {
+	v, err := c.get("Tables", f, func() (interface{}, error) { return c.r.Tables(f) })
+	res, ok := v.(*TableSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Columns(f Filter) (*ColumnSet, error) {
+	v, err := c.get("Columns", f, func() (interface{}, error) { return c.r.Columns(f) })
+	res, ok := v.(*ColumnSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) ColumnStats(f Filter) (*ColumnStatSet, error) {
+	v, err := c.get("ColumnStats", f, func() (interface{}, error) { return c.r.ColumnStats(f) })
+	res, ok := v.(*ColumnStatSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Indexes(f Filter) (*IndexSet, error) {
+	v, err := c.get("Indexes", f, func() (interface{}, error) { return c.r.Indexes(f) })
+	res, ok := v.(*IndexSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) IndexColumns(f Filter) (*IndexColumnSet, error) {
+	v, err := c.get("IndexColumns", f, func() (interface{}, error) { return c.r.IndexColumns(f) })
+	res, ok := v.(*IndexColumnSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Triggers(f Filter) (*TriggerSet, error) {
+	v, err := c.get("Triggers", f, func() (interface{}, error) { return c.r.Triggers(f) })
+	res, ok := v.(*TriggerSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Constraints(f Filter) (*ConstraintSet, error) {
+	v, err := c.get("Constraints", f, func() (interface{}, error) { return c.r.Constraints(f) })
+	res, ok := v.(*ConstraintSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) ConstraintColumns(f Filter) (*ConstraintColumnSet, error) {
+	v, err := c.get("ConstraintColumns", f, func() (interface{}, error) { return c.r.ConstraintColumns(f) })
+	res, ok := v.(*ConstraintColumnSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Functions(f Filter) (*FunctionSet, error) {
+	v, err := c.get("Functions", f, func() (interface{}, error) { return c.r.Functions(f) })
+	res, ok := v.(*FunctionSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) FunctionColumns(f Filter) (*FunctionColumnSet, error) {
+	v, err := c.get("FunctionColumns", f, func() (interface{}, error) { return c.r.FunctionColumns(f) })
+	res, ok := v.(*FunctionColumnSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Sequences(f Filter) (*SequenceSet, error) {
+	v, err := c.get("Sequences", f, func() (interface{}, error) { return c.r.Sequences(f) })
+	res, ok := v.(*SequenceSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) PrivilegeSummaries(f Filter) (*PrivilegeSummarySet, error) {
+	v, err := c.get("PrivilegeSummaries", f, func() (interface{}, error) { return c.r.PrivilegeSummaries(f) })
+	res, ok := v.(*PrivilegeSummarySet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Types(f Filter) (*TypeSet, error) {
+	v, err := c.get("Types", f, func() (interface{}, error) { return c.r.Types(f) })
+	res, ok := v.(*TypeSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Extensions(f Filter) (*ExtensionSet, error) {
+	v, err := c.get("Extensions", f, func() (interface{}, error) { return c.r.Extensions(f) })
+	res, ok := v.(*ExtensionSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) ExtensionObjects(f Filter) (*ExtensionObjectSet, error) {
+	v, err := c.get("ExtensionObjects", f, func() (interface{}, error) { return c.r.ExtensionObjects(f) })
+	res, ok := v.(*ExtensionObjectSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) ForeignTables(f Filter) (*ForeignTableSet, error) {
+	v, err := c.get("ForeignTables", f, func() (interface{}, error) { return c.r.ForeignTables(f) })
+	res, ok := v.(*ForeignTableSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) ForeignServers(f Filter) (*ForeignServerSet, error) {
+	v, err := c.get("ForeignServers", f, func() (interface{}, error) { return c.r.ForeignServers(f) })
+	res, ok := v.(*ForeignServerSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) ForeignDataWrappers(f Filter) (*ForeignDataWrapperSet, error) {
+	v, err := c.get("ForeignDataWrappers", f, func() (interface{}, error) { return c.r.ForeignDataWrappers(f) })
+	res, ok := v.(*ForeignDataWrapperSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) UserMappings(f Filter) (*UserMappingSet, error) {
+	v, err := c.get("UserMappings", f, func() (interface{}, error) { return c.r.UserMappings(f) })
+	res, ok := v.(*UserMappingSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
+
+func (c *CachingReader) Partitions(f Filter) (*PartitionSet, error) {
+	v, err := c.get("Partitions", f, func() (interface{}, error) { return c.r.Partitions(f) })
+	res, ok := v.(*PartitionSet)
+	if err != nil || !ok || res == nil {
+		return nil, err
+	}
+	s := *res
+	s.Reset()
+	return &s, nil
+}
@@ -0,0 +1,64 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+)
+
+type countingReader struct {
+	calls int
+}
+
+func (r *countingReader) Tables(f Filter) (*TableSet, error) {
+	r.calls++
+	return NewTableSet([]Table{{Schema: "public", Name: f.Name}}), nil
+}
+
+func TestCachingReader(t *testing.T) {
+	r := &countingReader{}
+	c := NewCachingReader(r, WithCacheSize(2))
+	for i := 0; i < 3; i++ {
+		res, err := c.Tables(Filter{Name: "a%"})
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+		// every result should be iterable from the start
+		if !res.Next() || res.Get().Name != "a%" || res.Next() {
+			t.Fatalf("expected a single table a%%, got %d results", res.Len())
+		}
+	}
+	if r.calls != 1 {
+		t.Errorf("expected 1 call for repeated filter, got %d", r.calls)
+	}
+	// different filters are cached separately, evicting the oldest
+	_, _ = c.Tables(Filter{Name: "b%"})
+	_, _ = c.Tables(Filter{Name: "c%"})
+	_, _ = c.Tables(Filter{Name: "c%", Types: []string{"VIEW"}})
+	if r.calls != 4 {
+		t.Errorf("expected 4 calls for distinct filters, got %d", r.calls)
+	}
+	_, _ = c.Tables(Filter{Name: "a%"})
+	if r.calls != 5 {
+		t.Errorf("expected 5 calls after eviction, got %d", r.calls)
+	}
+	c.Invalidate()
+	_, _ = c.Tables(Filter{Name: "a%"})
+	if r.calls != 6 {
+		t.Errorf("expected 6 calls after invalidation, got %d", r.calls)
+	}
+	// unsupported methods are not cached
+	if _, err := c.Schemas(Filter{}); err == nil {
+		t.Errorf("expected error for unsupported method")
+	}
+}
+
+func TestCachingReaderTTL(t *testing.T) {
+	r := &countingReader{}
+	c := NewCachingReader(r, WithCacheTTL(time.Millisecond))
+	_, _ = c.Tables(Filter{})
+	time.Sleep(5 * time.Millisecond)
+	_, _ = c.Tables(Filter{})
+	if r.calls != 2 {
+		t.Errorf("expected 2 calls after expiry, got %d", r.calls)
+	}
+}
@@ -47,6 +47,7 @@ const (
 	ColumnsNumericPrecRadix = ClauseName("columns.numeric_precision_radix")
 	ColumnsCharOctetLength  = ClauseName("columns.character_octet_length")
 
+	FunctionColumnsDataType         = ClauseName("function_columns.data_type")
 	FunctionColumnsColumnSize       = ClauseName("function_columns.column_size")
 	FunctionColumnsNumericScale     = ClauseName("function_columns.numeric_scale")
 	FunctionColumnsNumericPrecRadix = ClauseName("function_columns.numeric_precision_radix")
@@ -81,6 +82,7 @@ func New(opts ...metadata.ReaderOption) func(drivers.DB, ...metadata.ReaderOptio
 			ColumnsNumericScale:             "COALESCE(numeric_scale, 0)",
 			ColumnsNumericPrecRadix:         "COALESCE(numeric_precision_radix, 10)",
 			ColumnsCharOctetLength:          "COALESCE(character_octet_length, 0)",
+			FunctionColumnsDataType:         "COALESCE(data_type, '')",
 			FunctionColumnsColumnSize:       "COALESCE(character_maximum_length, numeric_precision, datetime_precision, 0)",
 			FunctionColumnsNumericScale:     "COALESCE(numeric_scale, 0)",
 			FunctionColumnsNumericPrecRadix: "COALESCE(numeric_precision_radix, 10)",
@@ -447,7 +449,7 @@ func (s InformationSchema) FunctionColumns(f metadata.Filter) (*metadata.Functio
 		"COALESCE(parameter_name, '')",
 		"ordinal_position",
 		"COALESCE(parameter_mode, '')",
-		"COALESCE(data_type, '')",
+		s.clauses[FunctionColumnsDataType],
 		s.clauses[FunctionColumnsColumnSize],
 		s.clauses[FunctionColumnsNumericScale],
 		s.clauses[FunctionColumnsNumericPrecRadix],
@@ -1,6 +1,7 @@
 package metadata
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/xo/dburl"
@@ -23,6 +24,14 @@ type ExtendedReader interface {
 	FunctionColumnReader
 	SequenceReader
 	PrivilegeSummaryReader
+	TypeReader
+	ExtensionReader
+	ExtensionObjectReader
+	ForeignTableReader
+	ForeignServerReader
+	ForeignDataWrapperReader
+	UserMappingReader
+	PartitionReader
 }
 
 // BasicReader of common database metadata like schemas, tables and columns.
@@ -50,6 +59,13 @@ type TableReader interface {
 	Tables(Filter) (*TableSet, error)
 }
 
+// TableRowReader counts rows of a table, for readers that do not return row
+// counts with tables because counting is expensive.
+type TableRowReader interface {
+	Reader
+	TableRows(Table) (int64, error)
+}
+
 // ColumnReader lists table columns.
 type ColumnReader interface {
 	Reader
@@ -116,6 +132,54 @@ type PrivilegeSummaryReader interface {
 	PrivilegeSummaries(Filter) (*PrivilegeSummarySet, error)
 }
 
+// TypeReader lists user defined data types, including domains.
+type TypeReader interface {
+	Reader
+	Types(Filter) (*TypeSet, error)
+}
+
+// ExtensionReader lists installed database extensions.
+type ExtensionReader interface {
+	Reader
+	Extensions(Filter) (*ExtensionSet, error)
+}
+
+// ExtensionObjectReader lists objects owned by extensions.
+type ExtensionObjectReader interface {
+	Reader
+	ExtensionObjects(Filter) (*ExtensionObjectSet, error)
+}
+
+// ForeignTableReader lists foreign tables.
+type ForeignTableReader interface {
+	Reader
+	ForeignTables(Filter) (*ForeignTableSet, error)
+}
+
+// ForeignServerReader lists foreign servers.
+type ForeignServerReader interface {
+	Reader
+	ForeignServers(Filter) (*ForeignServerSet, error)
+}
+
+// ForeignDataWrapperReader lists foreign data wrappers.
+type ForeignDataWrapperReader interface {
+	Reader
+	ForeignDataWrappers(Filter) (*ForeignDataWrapperSet, error)
+}
+
+// UserMappingReader lists user mappings for foreign servers.
+type UserMappingReader interface {
+	Reader
+	UserMappings(Filter) (*UserMappingSet, error)
+}
+
+// PartitionReader lists partitions of partitioned tables.
+type PartitionReader interface {
+	Reader
+	Partitions(Filter) (*PartitionSet, error)
+}
+
 // Reader of any database metadata in a structured format.
 type Reader interface{}
 
@@ -142,6 +206,49 @@ type Filter struct {
 	OnlyVisible bool
 }
 
+// LikeRegexp returns a regular expression matching the same strings as a SQL
+// LIKE pattern, for databases without a LIKE operator.
+func LikeRegexp(pattern string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '%':
+			sb.WriteString(".*")
+		case '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// LikeMatcher returns a func matching strings against a SQL LIKE pattern, or
+// matching all strings when the pattern is empty, for filtering names that
+// cannot be filtered by a query.
+func LikeMatcher(pattern string) func(string) bool {
+	if pattern == "" {
+		return func(string) bool { return true }
+	}
+	return regexp.MustCompile(LikeRegexp(pattern)).MatchString
+}
+
+// HasType returns whether types, as in a filter, is empty or contains typ,
+// for filtering types that cannot be filtered by a query.
+func HasType(types []string, typ string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
 // Writer of database metadata in a human readable format.
 type Writer interface {
 	// DescribeFunctions \df, \dfa, \dfn, \dft, \dfw, etc.
@@ -160,6 +267,28 @@ type Writer interface {
 	ShowStats(*dburl.URL, string, string, bool, int) error
 	// ListPrivilegeSummaries \dp
 	ListPrivilegeSummaries(*dburl.URL, string, bool) error
+	// ListTypes \dT
+	ListTypes(*dburl.URL, string, bool, bool) error
+	// ListDomains \dD
+	ListDomains(*dburl.URL, string, bool, bool) error
+	// ListExtensions \dx
+	ListExtensions(*dburl.URL, string, bool) error
+	// ListForeignTables \dE
+	ListForeignTables(*dburl.URL, string, bool, bool) error
+	// ListForeignServers \des
+	ListForeignServers(*dburl.URL, string, bool) error
+	// ListForeignDataWrappers \dew
+	ListForeignDataWrappers(*dburl.URL, string, bool) error
+	// ListUserMappings \deu
+	ListUserMappings(*dburl.URL, string, bool) error
+	// ListTriggers \dy
+	ListTriggers(*dburl.URL, string, bool, bool) error
+	// ListConstraints \dco
+	ListConstraints(*dburl.URL, string, bool, bool) error
+	// ListPartitions \dP
+	ListPartitions(*dburl.URL, string, bool) error
+	// DescribeSignatures \sig
+	DescribeSignatures(*dburl.URL, string) error
 }
 
 type CatalogSet struct {
@@ -1123,6 +1252,12 @@ type Trigger struct {
 	Table      string
 	Name       string
 	Definition string
+	// Timing is one of BEFORE, AFTER or INSTEAD OF
+	Timing string
+	// Event that fires the trigger, like INSERT OR UPDATE
+	Event    string
+	Enabled  string
+	Function string
 }
 
 func (t Trigger) Values() []interface{} {
@@ -1132,6 +1267,10 @@ func (t Trigger) Values() []interface{} {
 		t.Table,
 		t.Name,
 		t.Definition,
+		t.Timing,
+		t.Event,
+		t.Enabled,
+		t.Function,
 	}
 }
 
@@ -1153,6 +1292,10 @@ func NewTriggerSet(t []Trigger) *TriggerSet {
 				"Table",
 				"Name",
 				"Definition",
+				"Timing",
+				"Event",
+				"Enabled",
+				"Function",
 			},
 		},
 	}
@@ -1161,3 +1304,399 @@ func NewTriggerSet(t []Trigger) *TriggerSet {
 func (t TriggerSet) Get() *Trigger {
 	return t.results[t.current-1].(*Trigger)
 }
+
+type TypeSet struct {
+	resultSet
+}
+
+func NewTypeSet(v []Type) *TypeSet {
+	r := make([]Result, len(v))
+	for i := range v {
+		r[i] = &v[i]
+	}
+	return &TypeSet{
+		resultSet: resultSet{
+			results: r,
+			columns: []string{
+				"Catalog",
+				"Schema",
+				"Name",
+				"Kind",
+				"Base type",
+				"Elements",
+				"Constraints",
+				"Description",
+			},
+		},
+	}
+}
+
+func (t TypeSet) Get() *Type {
+	return t.results[t.current-1].(*Type)
+}
+
+// Type is a user defined data type, like an enum, composite type or domain
+type Type struct {
+	Catalog string
+	Schema  string
+	Name    string
+	// Kind is one of BASE, COMPOSITE, DOMAIN, ENUM, RANGE, or a driver specific kind
+	Kind     string
+	BaseType string
+	// Labels of an enum type
+	Labels      []string
+	Constraints string
+	Comment     string
+}
+
+func (t Type) Values() []interface{} {
+	return []interface{}{
+		t.Catalog,
+		t.Schema,
+		t.Name,
+		t.Kind,
+		t.BaseType,
+		strings.Join(t.Labels, "\n"),
+		t.Constraints,
+		t.Comment,
+	}
+}
+
+type ExtensionSet struct {
+	resultSet
+}
+
+func NewExtensionSet(v []Extension) *ExtensionSet {
+	r := make([]Result, len(v))
+	for i := range v {
+		r[i] = &v[i]
+	}
+	return &ExtensionSet{
+		resultSet: resultSet{
+			results: r,
+			columns: []string{
+				"Name",
+				"Version",
+				"Schema",
+				"Description",
+			},
+		},
+	}
+}
+
+func (e ExtensionSet) Get() *Extension {
+	return e.results[e.current-1].(*Extension)
+}
+
+// Extension is an installed or loaded database extension
+type Extension struct {
+	Catalog string
+	Schema  string
+	Name    string
+	Version string
+	Comment string
+}
+
+func (e Extension) Values() []interface{} {
+	return []interface{}{
+		e.Name,
+		e.Version,
+		e.Schema,
+		e.Comment,
+	}
+}
+
+type ExtensionObjectSet struct {
+	resultSet
+}
+
+func NewExtensionObjectSet(v []ExtensionObject) *ExtensionObjectSet {
+	r := make([]Result, len(v))
+	for i := range v {
+		r[i] = &v[i]
+	}
+	return &ExtensionObjectSet{
+		resultSet: resultSet{
+			results: r,
+			columns: []string{
+				"Extension",
+				"Type",
+				"Object description",
+			},
+		},
+	}
+}
+
+func (e ExtensionObjectSet) Get() *ExtensionObject {
+	return e.results[e.current-1].(*ExtensionObject)
+}
+
+// ExtensionObject is a database object that is a member of an extension
+type ExtensionObject struct {
+	Catalog     string
+	Schema      string
+	Extension   string
+	Type        string
+	Description string
+}
+
+func (e ExtensionObject) Values() []interface{} {
+	return []interface{}{
+		e.Extension,
+		e.Type,
+		e.Description,
+	}
+}
+
+type ForeignTableSet struct {
+	resultSet
+}
+
+func NewForeignTableSet(v []ForeignTable) *ForeignTableSet {
+	r := make([]Result, len(v))
+	for i := range v {
+		r[i] = &v[i]
+	}
+	return &ForeignTableSet{
+		resultSet: resultSet{
+			results: r,
+			columns: []string{
+				"Schema",
+				"Name",
+				"Server",
+				"FDW options",
+				"Description",
+			},
+		},
+	}
+}
+
+func (t ForeignTableSet) Get() *ForeignTable {
+	return t.results[t.current-1].(*ForeignTable)
+}
+
+// ForeignTable is a table stored on a foreign server
+type ForeignTable struct {
+	Catalog string
+	Schema  string
+	Name    string
+	Server  string
+	Options string
+	Comment string
+}
+
+func (t ForeignTable) Values() []interface{} {
+	return []interface{}{
+		t.Schema,
+		t.Name,
+		t.Server,
+		t.Options,
+		t.Comment,
+	}
+}
+
+type ForeignServerSet struct {
+	resultSet
+}
+
+func NewForeignServerSet(v []ForeignServer) *ForeignServerSet {
+	r := make([]Result, len(v))
+	for i := range v {
+		r[i] = &v[i]
+	}
+	return &ForeignServerSet{
+		resultSet: resultSet{
+			results: r,
+			columns: []string{
+				"Name",
+				"Owner",
+				"Foreign-data wrapper",
+				"Type",
+				"Version",
+				"FDW options",
+				"Description",
+			},
+		},
+	}
+}
+
+func (s ForeignServerSet) Get() *ForeignServer {
+	return s.results[s.current-1].(*ForeignServer)
+}
+
+// ForeignServer is a remote server accessed using a foreign data wrapper
+type ForeignServer struct {
+	Catalog string
+	Name    string
+	Owner   string
+	Wrapper string
+	Type    string
+	Version string
+	Options string
+	Comment string
+}
+
+func (s ForeignServer) Values() []interface{} {
+	return []interface{}{
+		s.Name,
+		s.Owner,
+		s.Wrapper,
+		s.Type,
+		s.Version,
+		s.Options,
+		s.Comment,
+	}
+}
+
+type ForeignDataWrapperSet struct {
+	resultSet
+}
+
+func NewForeignDataWrapperSet(v []ForeignDataWrapper) *ForeignDataWrapperSet {
+	r := make([]Result, len(v))
+	for i := range v {
+		r[i] = &v[i]
+	}
+	return &ForeignDataWrapperSet{
+		resultSet: resultSet{
+			results: r,
+			columns: []string{
+				"Name",
+				"Owner",
+				"Handler",
+				"Validator",
+				"FDW options",
+				"Description",
+			},
+		},
+	}
+}
+
+func (w ForeignDataWrapperSet) Get() *ForeignDataWrapper {
+	return w.results[w.current-1].(*ForeignDataWrapper)
+}
+
+// ForeignDataWrapper implements access to foreign servers
+type ForeignDataWrapper struct {
+	Catalog   string
+	Name      string
+	Owner     string
+	Handler   string
+	Validator string
+	Options   string
+	Comment   string
+}
+
+func (w ForeignDataWrapper) Values() []interface{} {
+	return []interface{}{
+		w.Name,
+		w.Owner,
+		w.Handler,
+		w.Validator,
+		w.Options,
+		w.Comment,
+	}
+}
+
+type UserMappingSet struct {
+	resultSet
+}
+
+func NewUserMappingSet(v []UserMapping) *UserMappingSet {
+	r := make([]Result, len(v))
+	for i := range v {
+		r[i] = &v[i]
+	}
+	return &UserMappingSet{
+		resultSet: resultSet{
+			results: r,
+			columns: []string{
+				"Server",
+				"User name",
+				"FDW options",
+			},
+		},
+	}
+}
+
+func (m UserMappingSet) Get() *UserMapping {
+	return m.results[m.current-1].(*UserMapping)
+}
+
+// UserMapping maps a local user to credentials on a foreign server
+type UserMapping struct {
+	Catalog string
+	Server  string
+	User    string
+	Options string
+}
+
+func (m UserMapping) Values() []interface{} {
+	return []interface{}{
+		m.Server,
+		m.User,
+		m.Options,
+	}
+}
+
+type PartitionSet struct {
+	resultSet
+}
+
+func NewPartitionSet(v []Partition) *PartitionSet {
+	r := make([]Result, len(v))
+	for i := range v {
+		r[i] = &v[i]
+	}
+	return &PartitionSet{
+		resultSet: resultSet{
+			results: r,
+			columns: []string{
+				"Catalog",
+				"Schema",
+				"Table",
+				"Name",
+				"Strategy",
+				"Key",
+				"Bounds",
+				"Rows",
+				"Size",
+			},
+		},
+	}
+}
+
+func (p PartitionSet) Get() *Partition {
+	return p.results[p.current-1].(*Partition)
+}
+
+// Partition of a partitioned table; Name is empty for tables without any partitions
+type Partition struct {
+	Catalog string
+	Schema  string
+	// Table is the partitioned (parent) table
+	Table string
+	Name  string
+	// Strategy is the partitioning method, like RANGE, LIST or HASH
+	Strategy string
+	// Key is the partition key expression of the parent table
+	Key string
+	// Bounds of values stored in the partition
+	Bounds string
+	Rows   int64
+	Size   string
+}
+
+func (p Partition) Values() []interface{} {
+	return []interface{}{
+		p.Catalog,
+		p.Schema,
+		p.Table,
+		p.Name,
+		p.Strategy,
+		p.Key,
+		p.Bounds,
+		p.Rows,
+		p.Size,
+	}
+}
@@ -173,3 +173,45 @@ func TestColumnPrivileges_String(t *testing.T) {
 		})
 	}
 }
+
+func TestLikeMatcher(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		want    bool
+	}{
+		{"", "film", true},
+		{"film", "film", true},
+		{"film", "films", false},
+		{"f%", "film", true},
+		{"f%", "actor", false},
+		{"fil_", "film", true},
+		{"fil_", "fil", false},
+		{"%.csv", "film.csv", true},
+		{"%.csv", "film_csv", false},
+		{"a(b)%", "a(b)c", true},
+	}
+	for _, tt := range tests {
+		if got := LikeMatcher(tt.pattern)(tt.s); got != tt.want {
+			t.Errorf("LikeMatcher(%q)(%q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestHasType(t *testing.T) {
+	tests := []struct {
+		types []string
+		typ   string
+		want  bool
+	}{
+		{nil, "TABLE", true},
+		{[]string{"TABLE"}, "TABLE", true},
+		{[]string{"VIEW"}, "TABLE", false},
+		{[]string{"VIEW", "TABLE"}, "TABLE", true},
+	}
+	for _, tt := range tests {
+		if got := HasType(tt.types, tt.typ); got != tt.want {
+			t.Errorf("HasType(%v, %q) = %v, want %v", tt.types, tt.typ, got, tt.want)
+		}
+	}
+}
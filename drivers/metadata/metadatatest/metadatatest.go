@@ -0,0 +1,31 @@
+// Package metadatatest provides a stub database for testing metadata readers.
+package metadatatest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// NewDB creates a stub database, using conv to convert query arguments, or
+// the default converter when nil. The database is closed, and checked for
+// unmet expectations, when the test ends.
+func NewDB(t *testing.T, conv driver.ValueConverter) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	if conv == nil {
+		conv = driver.DefaultParameterConverter
+	}
+	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(conv))
+	if err != nil {
+		t.Fatalf("could not create stub database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		db.Close()
+	})
+	return db, mock
+}
@@ -1,6 +1,9 @@
 package mysql
 
 import (
+	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gohxs/readline"
@@ -11,8 +14,7 @@ import (
 )
 
 var (
-	// NewReader for MySQL databases
-	NewReader = infos.New(
+	newIS = infos.New(
 		infos.WithPlaceholder(func(int) string { return "?" }),
 		infos.WithSequences(false),
 		infos.WithCheckConstraints(false),
@@ -29,6 +31,15 @@ var (
 		infos.WithCurrentSchema("COALESCE(DATABASE(), '%')"),
 		infos.WithUsagePrivileges(false),
 	)
+	// NewReader for MySQL databases
+	NewReader = func(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
+		return metadata.NewPluginReader(
+			newIS(db, opts...),
+			&metaReader{
+				LoggingReader: metadata.NewLoggingReader(db, opts...),
+			},
+		)
+	}
 	// NewCompleter for MySQL databases
 	NewCompleter = func(db drivers.DB, opts ...completer.Option) readline.AutoCompleter {
 		readerOpts := []metadata.ReaderOption{
@@ -36,17 +47,143 @@ var (
 			metadata.WithTimeout(3 * time.Second),
 			metadata.WithLimit(1000),
 		}
-		reader := NewReader(db, readerOpts...)
 		opts = append([]completer.Option{
-			completer.WithReader(reader),
+			completer.WithReader(NewReader(db, readerOpts...)),
 			completer.WithDB(db),
-			completer.WithSQLStartCommands(append(completer.CommonSqlStartCommands, "USE")),
-			completer.WithBeforeComplete(complete(reader)),
+			completer.WithReaderBeforeComplete(complete),
 		}, opts...)
 		return completer.NewDefaultCompleter(opts...)
 	}
+	// Dialect of MySQL, completed in addition to keywords of the mysql lexer
+	Dialect = completer.Dialect{
+		Keywords: []string{
+			"BEGIN",
+			"COMMIT",
+			"DEALLOCATE",
+			"DO",
+			"END",
+			"EXECUTE",
+			"PREPARE",
+			"ROLLBACK",
+			"SAVEPOINT",
+			"START",
+			"TRUNCATE",
+		},
+		StartCommands: []string{
+			"START TRANSACTION",
+			"USE",
+		},
+		Commands: []string{
+			"RIGHT JOIN",
+			"STRAIGHT_JOIN",
+		},
+		Functions: []string{
+			"COALESCE",
+			"CONCAT",
+			"CONCAT_WS",
+			"COUNT",
+			"CURDATE",
+			"DATE_FORMAT",
+			"GROUP_CONCAT",
+			"IF",
+			"IFNULL",
+			"JSON_EXTRACT",
+			"LAST_INSERT_ID",
+			"LENGTH",
+			"LOWER",
+			"MAX",
+			"MIN",
+			"NOW",
+			"NULLIF",
+			"SUBSTRING",
+			"SUM",
+			"UPPER",
+		},
+		Clauses: []completer.Clause{
+			{Statement: "INSERT", After: []string{"INSERT"}, Keywords: []string{"IGNORE"}},
+			{Statement: "INSERT", After: []string{"*)"}, Keywords: []string{"ON DUPLICATE KEY UPDATE"}},
+			{Statement: "SELECT", After: []string{"LIMIT", "*"}, Keywords: []string{"OFFSET", "FOR UPDATE", "LOCK IN SHARE MODE"}},
+			{Statement: "SELECT", After: []string{"SELECT"}, Keywords: []string{"SQL_CALC_FOUND_ROWS", "STRAIGHT_JOIN"}},
+		},
+	}
 )
 
+type metaReader struct {
+	metadata.LoggingReader
+	limit int
+}
+
+var _ metadata.PartitionReader = &metaReader{}
+
+func (r *metaReader) SetLimit(l int) {
+	r.limit = l
+}
+
+func (r metaReader) Partitions(f metadata.Filter) (*metadata.PartitionSet, error) {
+	qstr := `SELECT
+  table_catalog,
+  table_schema,
+  table_name,
+  CASE WHEN subpartition_name IS NULL THEN partition_name ELSE CONCAT(partition_name, '.', subpartition_name) END,
+  COALESCE(partition_method, ''),
+  COALESCE(partition_expression, ''),
+  CASE
+    WHEN partition_method LIKE 'RANGE%' THEN CONCAT('VALUES LESS THAN (', partition_description, ')')
+    WHEN partition_method LIKE 'LIST%' THEN CONCAT('VALUES IN (', partition_description, ')')
+    ELSE ''
+  END,
+  COALESCE(table_rows, 0),
+  CONCAT(ROUND((COALESCE(data_length, 0) + COALESCE(index_length, 0)) / 1024), ' kB')
+FROM information_schema.partitions`
+	conds := []string{"partition_name IS NOT NULL"}
+	vals := []interface{}{}
+	if f.OnlyVisible {
+		conds = append(conds, "table_schema = DATABASE()")
+	}
+	if !f.WithSystem {
+		conds = append(conds, "table_schema NOT IN ('mysql', 'information_schema', 'performance_schema', 'sys')")
+	}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, "table_schema LIKE ?")
+	}
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, "table_name LIKE ?")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "partition_name LIKE ?")
+	}
+	qstr += "\nWHERE " + strings.Join(conds, " AND ")
+	qstr += "\nORDER BY table_schema, table_name, partition_ordinal_position, subpartition_ordinal_position"
+	if r.limit != 0 {
+		qstr += fmt.Sprintf("\nLIMIT %d", r.limit)
+	}
+	rows, closeRows, err := r.Query(qstr, vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewPartitionSet([]metadata.Partition{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.Partition{}
+	for rows.Next() {
+		rec := metadata.Partition{}
+		err = rows.Scan(&rec.Catalog, &rec.Schema, &rec.Table, &rec.Name, &rec.Strategy, &rec.Key, &rec.Bounds, &rec.Rows, &rec.Size)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewPartitionSet(results), nil
+}
+
 func complete(reader metadata.Reader) completer.CompleteFunc {
 	return func(previousWords []string, text []rune) [][]rune {
 		if completer.TailMatches(completer.IGNORE_CASE, previousWords, `USE`) {
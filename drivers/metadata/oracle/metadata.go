@@ -18,6 +18,8 @@ type metaReader struct {
 var _ metadata.BasicReader = &metaReader{}
 var _ metadata.IndexReader = &metaReader{}
 var _ metadata.IndexColumnReader = &metaReader{}
+var _ metadata.TypeReader = &metaReader{}
+var _ metadata.PartitionReader = &metaReader{}
 
 func NewReader() func(drivers.DB, ...metadata.ReaderOption) metadata.Reader {
 	return func(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
@@ -434,6 +436,124 @@ ORDER BY o.owner, o.table_name, o.index_name, b.column_position`
 	return metadata.NewIndexColumnSet(results), nil
 }
 
+func (r metaReader) Types(f metadata.Filter) (*metadata.TypeSet, error) {
+	qstr := `SELECT
+  t.owner,
+  t.type_name,
+  t.typecode,
+  t.supertype_name,
+  c.coll_type,
+  c.upper_bound,
+  c.elem_type_name
+FROM all_types t
+LEFT JOIN all_coll_types c ON c.owner = t.owner AND c.type_name = t.type_name
+`
+	conds, vals := r.conditions(f, formats{
+		schema:     "t.owner LIKE %s",
+		notSchemas: "t.owner NOT IN (%s)",
+		name:       "t.type_name LIKE :%d",
+		types:      "t.typecode IN (%s)",
+	})
+	if len(conds) != 0 {
+		qstr += " WHERE " + strings.Join(conds, " AND ")
+	}
+	qstr += `
+ORDER BY t.owner, t.type_name`
+	rows, closeRows, err := r.Query(qstr, vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewTypeSet([]metadata.Type{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.Type{}
+	for rows.Next() {
+		rec := metadata.Type{}
+		var schema, supertype, collType, elemType sql.NullString
+		var upperBound sql.NullInt64
+		err = rows.Scan(&schema, &rec.Name, &rec.Kind, &supertype, &collType, &upperBound, &elemType)
+		if err != nil {
+			return nil, err
+		}
+		rec.Schema = schema.String
+		switch {
+		case collType.String == "VARYING ARRAY":
+			rec.BaseType = fmt.Sprintf("VARRAY(%d) OF %s", upperBound.Int64, elemType.String)
+		case collType.Valid:
+			rec.BaseType = fmt.Sprintf("%s OF %s", collType.String, elemType.String)
+		default:
+			rec.BaseType = supertype.String
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewTypeSet(results), nil
+}
+
+func (r metaReader) Partitions(f metadata.Filter) (*metadata.PartitionSet, error) {
+	qstr := `SELECT
+  t.owner,
+  t.table_name,
+  p.partition_name,
+  t.partitioning_type,
+  (SELECT LISTAGG(k.column_name, ', ') WITHIN GROUP (ORDER BY k.column_position)
+   FROM all_part_key_columns k
+   WHERE k.owner = t.owner AND k.name = t.table_name AND k.object_type = 'TABLE') AS partition_key,
+  NVL(p.num_rows, 0),
+  ROUND(NVL(p.blocks, 0) * NVL(ts.block_size, 0) / 1024) || ' kB',
+  p.high_value
+FROM all_part_tables t
+JOIN all_tab_partitions p ON p.table_owner = t.owner AND p.table_name = t.table_name
+LEFT JOIN user_tablespaces ts ON ts.tablespace_name = p.tablespace_name
+`
+	conds, vals := r.conditions(f, formats{
+		schema:     "t.owner LIKE %s",
+		notSchemas: "t.owner NOT IN (%s)",
+		parent:     "t.table_name LIKE :%d",
+		name:       "p.partition_name LIKE :%d",
+	})
+	if len(conds) != 0 {
+		qstr += " WHERE " + strings.Join(conds, " AND ")
+	}
+	qstr += `
+ORDER BY t.owner, t.table_name, p.partition_position`
+	rows, closeRows, err := r.Query(qstr, vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewPartitionSet([]metadata.Partition{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.Partition{}
+	for rows.Next() {
+		rec := metadata.Partition{}
+		var key, highValue sql.NullString
+		err = rows.Scan(&rec.Schema, &rec.Table, &rec.Name, &rec.Strategy, &key, &rec.Rows, &rec.Size, &highValue)
+		if err != nil {
+			return nil, err
+		}
+		rec.Key = key.String
+		switch {
+		case !highValue.Valid:
+		case rec.Strategy == "RANGE":
+			rec.Bounds = fmt.Sprintf("VALUES LESS THAN (%s)", highValue.String)
+		case rec.Strategy == "LIST":
+			rec.Bounds = fmt.Sprintf("VALUES (%s)", highValue.String)
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewPartitionSet(results), nil
+}
+
 func (r metaReader) conditions(filter metadata.Filter, formats formats) ([]string, []interface{}) {
 	baseParam := 1
 	conds := []string{}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/lib/pq"
 	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/completer"
 	"github.com/xo/usql/drivers/metadata"
 	infos "github.com/xo/usql/drivers/metadata/informationschema"
 )
@@ -23,6 +24,14 @@ var _ metadata.ColumnStatReader = &metaReader{}
 var _ metadata.IndexReader = &metaReader{}
 var _ metadata.IndexColumnReader = &metaReader{}
 var _ metadata.TriggerReader = &metaReader{}
+var _ metadata.TypeReader = &metaReader{}
+var _ metadata.ExtensionReader = &metaReader{}
+var _ metadata.ExtensionObjectReader = &metaReader{}
+var _ metadata.ForeignTableReader = &metaReader{}
+var _ metadata.ForeignServerReader = &metaReader{}
+var _ metadata.ForeignDataWrapperReader = &metaReader{}
+var _ metadata.UserMappingReader = &metaReader{}
+var _ metadata.PartitionReader = &metaReader{}
 
 func NewReader() func(drivers.DB, ...metadata.ReaderOption) metadata.Reader {
 	return func(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
@@ -31,6 +40,8 @@ func NewReader() func(drivers.DB, ...metadata.ReaderOption) metadata.Reader {
 			infos.WithCustomClauses(map[infos.ClauseName]string{
 				infos.ColumnsColumnSize:         "COALESCE(character_maximum_length, numeric_precision, datetime_precision, interval_precision, 0)",
 				infos.FunctionColumnsColumnSize: "COALESCE(character_maximum_length, numeric_precision, datetime_precision, interval_precision, 0)",
+				// name user defined types, ie enums
+				infos.FunctionColumnsDataType: "CASE WHEN data_type = 'USER-DEFINED' THEN udt_schema || '.' || udt_name ELSE COALESCE(data_type, '') END",
 			}),
 			infos.WithSystemSchemas([]string{"pg_catalog", "pg_toast", "information_schema"}),
 			infos.WithCurrentSchema("CURRENT_SCHEMA"),
@@ -44,6 +55,44 @@ func NewReader() func(drivers.DB, ...metadata.ReaderOption) metadata.Reader {
 	}
 }
 
+// Dialect of PostgreSQL, completed in addition to keywords of the postgres lexer
+var Dialect = completer.Dialect{
+	Keywords: []string{
+		"CALL",
+	},
+	Commands: []string{
+		"ILIKE",
+		"RETURNING",
+	},
+	Functions: []string{
+		"ARRAY_AGG",
+		"AVG",
+		"COALESCE",
+		"COUNT",
+		"DATE_TRUNC",
+		"GENERATE_SERIES",
+		"GREATEST",
+		"JSON_AGG",
+		"LEAST",
+		"LENGTH",
+		"LOWER",
+		"MAX",
+		"MIN",
+		"NOW",
+		"NULLIF",
+		"STRING_AGG",
+		"SUM",
+		"UPPER",
+	},
+	Clauses: []completer.Clause{
+		{Statement: "INSERT", After: []string{"*)"}, Keywords: []string{"ON CONFLICT", "RETURNING"}},
+		{Statement: "INSERT", After: []string{"ON", "CONFLICT"}, Keywords: []string{"DO NOTHING", "DO UPDATE SET"}},
+		{Statement: "INSERT", After: []string{"CONFLICT", "*)"}, Keywords: []string{"DO NOTHING", "DO UPDATE SET"}},
+		{Statement: "UPDATE|DELETE", After: []string{"=|<>|!=|<|>|<=|>=", "*"}, Keywords: []string{"RETURNING"}},
+		{Statement: "SELECT", After: []string{"SELECT"}, Keywords: []string{"DISTINCT ON"}},
+	},
+}
+
 func dataTypeFormatter(col metadata.Column) string {
 	switch col.DataType {
 	case "bit", "character":
@@ -157,10 +206,11 @@ FROM pg_catalog.pg_class c
 	}
 	if len(f.Types) != 0 {
 		tableTypes := map[string][]rune{
-			"TABLE":             {'r', 'p', 's', 'f'},
+			"TABLE":             {'r', 'p', 's'},
 			"VIEW":              {'v'},
 			"MATERIALIZED VIEW": {'m'},
 			"SEQUENCE":          {'S'},
+			"FOREIGN TABLE":     {'f'},
 		}
 		pholders := []string{"''"}
 		for _, t := range f.Types {
@@ -395,7 +445,16 @@ func (r metaReader) Triggers(f metadata.Filter) (*metadata.TriggerSet, error) {
 	n.nspname,
 	c.relname,
     t.tgname, 
-    pg_catalog.pg_get_triggerdef(t.oid, true)
+    pg_catalog.pg_get_triggerdef(t.oid, true),
+    CASE WHEN t.tgtype::integer & 2 <> 0 THEN 'BEFORE' WHEN t.tgtype::integer & 64 <> 0 THEN 'INSTEAD OF' ELSE 'AFTER' END,
+    pg_catalog.array_to_string(ARRAY[
+      CASE WHEN t.tgtype::integer & 4 <> 0 THEN 'INSERT' END,
+      CASE WHEN t.tgtype::integer & 16 <> 0 THEN 'UPDATE' END,
+      CASE WHEN t.tgtype::integer & 8 <> 0 THEN 'DELETE' END,
+      CASE WHEN t.tgtype::integer & 32 <> 0 THEN 'TRUNCATE' END
+    ], ' OR '),
+    CASE t.tgenabled WHEN 'D' THEN 'disabled' WHEN 'R' THEN 'replica' WHEN 'A' THEN 'always' ELSE 'enabled' END,
+    t.tgfoid::pg_catalog.regproc::text
 FROM 
     pg_catalog.pg_trigger t 
     JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid
@@ -408,6 +467,10 @@ FROM
 				refclassid = 'pg_catalog.pg_trigger'::pg_catalog.regclass)
 	)`}
 	vals := []interface{}{}
+	if !f.WithSystem && f.Parent == "" {
+		// triggers of a specific table are always listed when describing it
+		conds = append(conds, "n.nspname NOT IN ('pg_catalog', 'information_schema')")
+	}
 	if f.Schema != "" {
 		vals = append(vals, f.Schema)
 		conds = append(conds, fmt.Sprintf("n.nspname LIKE $%d", len(vals)))
@@ -434,6 +497,10 @@ FROM
 			&rec.Table,
 			&rec.Name,
 			&rec.Definition,
+			&rec.Timing,
+			&rec.Event,
+			&rec.Enabled,
+			&rec.Function,
 		)
 		if err != nil {
 			return nil, err
@@ -446,6 +513,433 @@ FROM
 	return metadata.NewTriggerSet(results), nil
 }
 
+func (r metaReader) Types(f metadata.Filter) (*metadata.TypeSet, error) {
+	qstr := `SELECT
+  n.nspname,
+  pg_catalog.format_type(t.oid, NULL),
+  CASE t.typtype WHEN 'b' THEN 'BASE' WHEN 'c' THEN 'COMPOSITE' WHEN 'd' THEN 'DOMAIN' WHEN 'e' THEN 'ENUM' WHEN 'p' THEN 'PSEUDO' WHEN 'r' THEN 'RANGE' WHEN 'm' THEN 'MULTIRANGE' ELSE 'UNKNOWN' END,
+  CASE WHEN t.typtype = 'd' THEN pg_catalog.format_type(t.typbasetype, t.typtypmod) ELSE '' END,
+  ARRAY(SELECT e.enumlabel FROM pg_catalog.pg_enum e WHERE e.enumtypid = t.oid ORDER BY e.enumsortorder),
+  pg_catalog.array_to_string(ARRAY(
+    SELECT 'NOT NULL' WHERE t.typnotnull
+    UNION ALL
+    SELECT 'DEFAULT ' || t.typdefault WHERE t.typdefault IS NOT NULL
+    UNION ALL
+    SELECT pg_catalog.pg_get_constraintdef(r.oid, true) FROM pg_catalog.pg_constraint r WHERE r.contypid = t.oid
+  ), ' '),
+  COALESCE(pg_catalog.obj_description(t.oid, 'pg_type'), '')
+FROM pg_catalog.pg_type t
+     LEFT JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+`
+	conds := []string{
+		"(t.typrelid = 0 OR (SELECT c.relkind = 'c' FROM pg_catalog.pg_class c WHERE c.oid = t.typrelid))",
+		"NOT EXISTS (SELECT 1 FROM pg_catalog.pg_type el WHERE el.oid = t.typelem AND el.typarray = t.oid)",
+	}
+	vals := []interface{}{}
+	if f.OnlyVisible {
+		conds = append(conds, "pg_catalog.pg_type_is_visible(t.oid)")
+	}
+	if !f.WithSystem {
+		conds = append(conds, "n.nspname NOT IN ('pg_catalog', 'information_schema')")
+	}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, fmt.Sprintf("n.nspname LIKE $%d", len(vals)))
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, fmt.Sprintf("(t.typname LIKE $%d OR pg_catalog.format_type(t.oid, NULL) LIKE $%d)", len(vals), len(vals)))
+	}
+	if len(f.Types) != 0 {
+		typeKinds := map[string]rune{
+			"BASE":       'b',
+			"COMPOSITE":  'c',
+			"DOMAIN":     'd',
+			"ENUM":       'e',
+			"PSEUDO":     'p',
+			"RANGE":      'r',
+			"MULTIRANGE": 'm',
+		}
+		pholders := []string{"''"}
+		for _, t := range f.Types {
+			if k, ok := typeKinds[t]; ok {
+				vals = append(vals, string(k))
+				pholders = append(pholders, fmt.Sprintf("$%d", len(vals)))
+			}
+		}
+		conds = append(conds, fmt.Sprintf("t.typtype IN (%s)", strings.Join(pholders, ", ")))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "1, 2", vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewTypeSet([]metadata.Type{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.Type{}
+	for rows.Next() {
+		rec := metadata.Type{}
+		err = rows.Scan(
+			&rec.Schema,
+			&rec.Name,
+			&rec.Kind,
+			&rec.BaseType,
+			pq.Array(&rec.Labels),
+			&rec.Constraints,
+			&rec.Comment,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewTypeSet(results), nil
+}
+
+func (r metaReader) Extensions(f metadata.Filter) (*metadata.ExtensionSet, error) {
+	qstr := `SELECT
+  n.nspname,
+  e.extname,
+  e.extversion,
+  COALESCE(c.description, '')
+FROM pg_catalog.pg_extension e
+     LEFT JOIN pg_catalog.pg_namespace n ON n.oid = e.extnamespace
+     LEFT JOIN pg_catalog.pg_description c ON c.objoid = e.oid AND c.classoid = 'pg_catalog.pg_extension'::pg_catalog.regclass
+`
+	conds := []string{}
+	vals := []interface{}{}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, fmt.Sprintf("n.nspname LIKE $%d", len(vals)))
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, fmt.Sprintf("e.extname LIKE $%d", len(vals)))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "e.extname", vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewExtensionSet([]metadata.Extension{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.Extension{}
+	for rows.Next() {
+		rec := metadata.Extension{}
+		err = rows.Scan(&rec.Schema, &rec.Name, &rec.Version, &rec.Comment)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewExtensionSet(results), nil
+}
+
+func (r metaReader) ExtensionObjects(f metadata.Filter) (*metadata.ExtensionObjectSet, error) {
+	qstr := `SELECT
+  COALESCE(o.schema, ''),
+  e.extname,
+  o.type,
+  pg_catalog.pg_describe_object(d.classid, d.objid, 0)
+FROM pg_catalog.pg_depend d
+     JOIN pg_catalog.pg_extension e ON e.oid = d.refobjid
+     CROSS JOIN LATERAL pg_catalog.pg_identify_object(d.classid, d.objid, 0) o
+`
+	conds := []string{
+		"d.refclassid = 'pg_catalog.pg_extension'::pg_catalog.regclass",
+		"d.deptype = 'e'",
+	}
+	vals := []interface{}{}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, fmt.Sprintf("o.schema LIKE $%d", len(vals)))
+	}
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, fmt.Sprintf("e.extname LIKE $%d", len(vals)))
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, fmt.Sprintf("o.name LIKE $%d", len(vals)))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "2, 4", vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewExtensionObjectSet([]metadata.ExtensionObject{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.ExtensionObject{}
+	for rows.Next() {
+		rec := metadata.ExtensionObject{}
+		err = rows.Scan(&rec.Schema, &rec.Extension, &rec.Type, &rec.Description)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewExtensionObjectSet(results), nil
+}
+
+// fdwOptions formats an array of generic options, like ftoptions or srvoptions
+func fdwOptions(col string) string {
+	return `CASE WHEN ` + col + ` IS NULL THEN '' ELSE '(' || pg_catalog.array_to_string(ARRAY(
+    SELECT pg_catalog.quote_ident(option_name) || ' ' || pg_catalog.quote_literal(option_value)
+    FROM pg_catalog.pg_options_to_table(` + col + `)), ', ') || ')' END`
+}
+
+func (r metaReader) ForeignTables(f metadata.Filter) (*metadata.ForeignTableSet, error) {
+	qstr := `SELECT
+  n.nspname,
+  c.relname,
+  s.srvname,
+  ` + fdwOptions("ft.ftoptions") + `,
+  COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '')
+FROM pg_catalog.pg_foreign_table ft
+     JOIN pg_catalog.pg_class c ON c.oid = ft.ftrelid
+     JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+     JOIN pg_catalog.pg_foreign_server s ON s.oid = ft.ftserver
+`
+	conds := []string{}
+	vals := []interface{}{}
+	if f.OnlyVisible {
+		conds = append(conds, "pg_catalog.pg_table_is_visible(c.oid)")
+	}
+	if !f.WithSystem {
+		conds = append(conds, "n.nspname NOT IN ('pg_catalog', 'information_schema')")
+	}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, fmt.Sprintf("n.nspname LIKE $%d", len(vals)))
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, fmt.Sprintf("c.relname LIKE $%d", len(vals)))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "1, 2", vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewForeignTableSet([]metadata.ForeignTable{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.ForeignTable{}
+	for rows.Next() {
+		rec := metadata.ForeignTable{}
+		err = rows.Scan(&rec.Schema, &rec.Name, &rec.Server, &rec.Options, &rec.Comment)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewForeignTableSet(results), nil
+}
+
+func (r metaReader) ForeignServers(f metadata.Filter) (*metadata.ForeignServerSet, error) {
+	qstr := `SELECT
+  s.srvname,
+  pg_catalog.pg_get_userbyid(s.srvowner),
+  w.fdwname,
+  COALESCE(s.srvtype, ''),
+  COALESCE(s.srvversion, ''),
+  ` + fdwOptions("s.srvoptions") + `,
+  COALESCE(d.description, '')
+FROM pg_catalog.pg_foreign_server s
+     JOIN pg_catalog.pg_foreign_data_wrapper w ON w.oid = s.srvfdw
+     LEFT JOIN pg_catalog.pg_description d ON d.classoid = s.tableoid AND d.objoid = s.oid AND d.objsubid = 0
+`
+	conds := []string{}
+	vals := []interface{}{}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, fmt.Sprintf("s.srvname LIKE $%d", len(vals)))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "1", vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewForeignServerSet([]metadata.ForeignServer{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.ForeignServer{}
+	for rows.Next() {
+		rec := metadata.ForeignServer{}
+		err = rows.Scan(&rec.Name, &rec.Owner, &rec.Wrapper, &rec.Type, &rec.Version, &rec.Options, &rec.Comment)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewForeignServerSet(results), nil
+}
+
+func (r metaReader) ForeignDataWrappers(f metadata.Filter) (*metadata.ForeignDataWrapperSet, error) {
+	qstr := `SELECT
+  w.fdwname,
+  pg_catalog.pg_get_userbyid(w.fdwowner),
+  CASE WHEN w.fdwhandler = 0 THEN '' ELSE w.fdwhandler::pg_catalog.regproc::text END,
+  CASE WHEN w.fdwvalidator = 0 THEN '' ELSE w.fdwvalidator::pg_catalog.regproc::text END,
+  ` + fdwOptions("w.fdwoptions") + `,
+  COALESCE(d.description, '')
+FROM pg_catalog.pg_foreign_data_wrapper w
+     LEFT JOIN pg_catalog.pg_description d ON d.classoid = w.tableoid AND d.objoid = w.oid AND d.objsubid = 0
+`
+	conds := []string{}
+	vals := []interface{}{}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, fmt.Sprintf("w.fdwname LIKE $%d", len(vals)))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "1", vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewForeignDataWrapperSet([]metadata.ForeignDataWrapper{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.ForeignDataWrapper{}
+	for rows.Next() {
+		rec := metadata.ForeignDataWrapper{}
+		err = rows.Scan(&rec.Name, &rec.Owner, &rec.Handler, &rec.Validator, &rec.Options, &rec.Comment)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewForeignDataWrapperSet(results), nil
+}
+
+func (r metaReader) UserMappings(f metadata.Filter) (*metadata.UserMappingSet, error) {
+	qstr := `SELECT
+  um.srvname,
+  um.usename,
+  ` + fdwOptions("um.umoptions") + `
+FROM pg_catalog.pg_user_mappings um
+`
+	conds := []string{}
+	vals := []interface{}{}
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, fmt.Sprintf("um.srvname LIKE $%d", len(vals)))
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, fmt.Sprintf("um.usename LIKE $%d", len(vals)))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "1, 2", vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewUserMappingSet([]metadata.UserMapping{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.UserMapping{}
+	for rows.Next() {
+		rec := metadata.UserMapping{}
+		err = rows.Scan(&rec.Server, &rec.User, &rec.Options)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewUserMappingSet(results), nil
+}
+
+func (r metaReader) Partitions(f metadata.Filter) (*metadata.PartitionSet, error) {
+	qstr := `SELECT
+  n.nspname,
+  pc.relname,
+  COALESCE(c.relname, ''),
+  CASE pt.partstrat WHEN 'r' THEN 'RANGE' WHEN 'l' THEN 'LIST' WHEN 'h' THEN 'HASH' ELSE 'UNKNOWN' END,
+  pg_catalog.regexp_replace(pg_catalog.pg_get_partkeydef(pc.oid), '^\w+ \((.*)\)$', '\1'),
+  COALESCE(pg_catalog.pg_get_expr(c.relpartbound, c.oid), ''),
+  COALESCE(GREATEST(c.reltuples, 0), 0)::bigint,
+  COALESCE(pg_catalog.pg_size_pretty(pg_catalog.pg_table_size(c.oid)), '')
+FROM pg_catalog.pg_partitioned_table pt
+     JOIN pg_catalog.pg_class pc ON pc.oid = pt.partrelid
+     JOIN pg_catalog.pg_namespace n ON n.oid = pc.relnamespace
+     LEFT JOIN pg_catalog.pg_inherits i ON i.inhparent = pc.oid
+     LEFT JOIN pg_catalog.pg_class c ON c.oid = i.inhrelid
+`
+	conds := []string{}
+	vals := []interface{}{}
+	if f.OnlyVisible {
+		conds = append(conds, "pg_catalog.pg_table_is_visible(pc.oid)")
+	}
+	if !f.WithSystem {
+		conds = append(conds, "n.nspname NOT IN ('pg_catalog', 'information_schema')")
+	}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, fmt.Sprintf("n.nspname LIKE $%d", len(vals)))
+	}
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, fmt.Sprintf("pc.relname LIKE $%d", len(vals)))
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, fmt.Sprintf("c.relname LIKE $%d", len(vals)))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "1, 2, 3", vals...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return metadata.NewPartitionSet([]metadata.Partition{}), nil
+		}
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.Partition{}
+	for rows.Next() {
+		rec := metadata.Partition{}
+		err = rows.Scan(&rec.Schema, &rec.Table, &rec.Name, &rec.Strategy, &rec.Key, &rec.Bounds, &rec.Rows, &rec.Size)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewPartitionSet(results), nil
+}
+
 func (r metaReader) query(qstr string, conds []string, order string, vals ...interface{}) (*sql.Rows, func(), error) {
 	if len(conds) != 0 {
 		qstr += "\nWHERE " + strings.Join(conds, " AND ")
@@ -10,20 +10,28 @@ import (
 
 // PluginReader allows to be easily composed from other readers
 type PluginReader struct {
-	catalogs           func(Filter) (*CatalogSet, error)
-	schemas            func(Filter) (*SchemaSet, error)
-	tables             func(Filter) (*TableSet, error)
-	columns            func(Filter) (*ColumnSet, error)
-	columnStats        func(Filter) (*ColumnStatSet, error)
-	indexes            func(Filter) (*IndexSet, error)
-	indexColumns       func(Filter) (*IndexColumnSet, error)
-	triggers           func(Filter) (*TriggerSet, error)
-	constraints        func(Filter) (*ConstraintSet, error)
-	constraintColumns  func(Filter) (*ConstraintColumnSet, error)
-	functions          func(Filter) (*FunctionSet, error)
-	functionColumns    func(Filter) (*FunctionColumnSet, error)
-	sequences          func(Filter) (*SequenceSet, error)
-	privilegeSummaries func(Filter) (*PrivilegeSummarySet, error)
+	catalogs            func(Filter) (*CatalogSet, error)
+	schemas             func(Filter) (*SchemaSet, error)
+	tables              func(Filter) (*TableSet, error)
+	columns             func(Filter) (*ColumnSet, error)
+	columnStats         func(Filter) (*ColumnStatSet, error)
+	indexes             func(Filter) (*IndexSet, error)
+	indexColumns        func(Filter) (*IndexColumnSet, error)
+	triggers            func(Filter) (*TriggerSet, error)
+	constraints         func(Filter) (*ConstraintSet, error)
+	constraintColumns   func(Filter) (*ConstraintColumnSet, error)
+	functions           func(Filter) (*FunctionSet, error)
+	functionColumns     func(Filter) (*FunctionColumnSet, error)
+	sequences           func(Filter) (*SequenceSet, error)
+	privilegeSummaries  func(Filter) (*PrivilegeSummarySet, error)
+	types               func(Filter) (*TypeSet, error)
+	extensions          func(Filter) (*ExtensionSet, error)
+	extensionObjects    func(Filter) (*ExtensionObjectSet, error)
+	foreignTables       func(Filter) (*ForeignTableSet, error)
+	foreignServers      func(Filter) (*ForeignServerSet, error)
+	foreignDataWrappers func(Filter) (*ForeignDataWrapperSet, error)
+	userMappings        func(Filter) (*UserMappingSet, error)
+	partitions          func(Filter) (*PartitionSet, error)
 }
 
 var _ ExtendedReader = &PluginReader{}
@@ -74,6 +82,30 @@ func NewPluginReader(readers ...Reader) Reader {
 		if r, ok := i.(PrivilegeSummaryReader); ok {
 			p.privilegeSummaries = r.PrivilegeSummaries
 		}
+		if r, ok := i.(TypeReader); ok {
+			p.types = r.Types
+		}
+		if r, ok := i.(ExtensionReader); ok {
+			p.extensions = r.Extensions
+		}
+		if r, ok := i.(ExtensionObjectReader); ok {
+			p.extensionObjects = r.ExtensionObjects
+		}
+		if r, ok := i.(ForeignTableReader); ok {
+			p.foreignTables = r.ForeignTables
+		}
+		if r, ok := i.(ForeignServerReader); ok {
+			p.foreignServers = r.ForeignServers
+		}
+		if r, ok := i.(ForeignDataWrapperReader); ok {
+			p.foreignDataWrappers = r.ForeignDataWrappers
+		}
+		if r, ok := i.(UserMappingReader); ok {
+			p.userMappings = r.UserMappings
+		}
+		if r, ok := i.(PartitionReader); ok {
+			p.partitions = r.Partitions
+		}
 	}
 	return &p
 }
@@ -176,6 +208,62 @@ func (p PluginReader) PrivilegeSummaries(f Filter) (*PrivilegeSummarySet, error)
 	return p.privilegeSummaries(f)
 }
 
+func (p PluginReader) Types(f Filter) (*TypeSet, error) {
+	if p.types == nil {
+		return nil, text.ErrNotSupported
+	}
+	return p.types(f)
+}
+
+func (p PluginReader) Extensions(f Filter) (*ExtensionSet, error) {
+	if p.extensions == nil {
+		return nil, text.ErrNotSupported
+	}
+	return p.extensions(f)
+}
+
+func (p PluginReader) ExtensionObjects(f Filter) (*ExtensionObjectSet, error) {
+	if p.extensionObjects == nil {
+		return nil, text.ErrNotSupported
+	}
+	return p.extensionObjects(f)
+}
+
+func (p PluginReader) ForeignTables(f Filter) (*ForeignTableSet, error) {
+	if p.foreignTables == nil {
+		return nil, text.ErrNotSupported
+	}
+	return p.foreignTables(f)
+}
+
+func (p PluginReader) ForeignServers(f Filter) (*ForeignServerSet, error) {
+	if p.foreignServers == nil {
+		return nil, text.ErrNotSupported
+	}
+	return p.foreignServers(f)
+}
+
+func (p PluginReader) ForeignDataWrappers(f Filter) (*ForeignDataWrapperSet, error) {
+	if p.foreignDataWrappers == nil {
+		return nil, text.ErrNotSupported
+	}
+	return p.foreignDataWrappers(f)
+}
+
+func (p PluginReader) UserMappings(f Filter) (*UserMappingSet, error) {
+	if p.userMappings == nil {
+		return nil, text.ErrNotSupported
+	}
+	return p.userMappings(f)
+}
+
+func (p PluginReader) Partitions(f Filter) (*PartitionSet, error) {
+	if p.partitions == nil {
+		return nil, text.ErrNotSupported
+	}
+	return p.partitions(f)
+}
+
 type LoggingReader struct {
 	db      DB
 	logger  logger
@@ -47,6 +47,7 @@ func NewDefaultWriter(r Reader, opts ...WriterOption) func(db DB, w io.Writer) W
 			'v': {"VIEW", "SYSTEM VIEW"},
 			'm': {"MATERIALIZED VIEW"},
 			's': {"SEQUENCE"},
+			'E': {"FOREIGN TABLE", "FOREIGN"},
 		},
 		funcTypes: map[rune][]string{
 			'a': {"AGGREGATE"},
@@ -148,6 +149,49 @@ func (w DefaultWriter) DescribeFunctions(u *dburl.URL, funcTypes, pattern string
 	return tblfmt.EncodeAll(w.w, res, params)
 }
 
+// DescribeSignatures of functions matching pattern
+func (w DefaultWriter) DescribeSignatures(u *dburl.URL, pattern string) error {
+	r, ok := w.r.(FunctionReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\sig`, u.Driver)
+	}
+	sp, tp, err := parsePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to parse search pattern: %w", err)
+	}
+	res, err := r.Functions(Filter{Schema: sp, Name: tp, WithSystem: true})
+	if err != nil {
+		return fmt.Errorf("failed to list functions: %w", err)
+	}
+	defer res.Close()
+
+	if res.Len() == 0 {
+		fmt.Fprintf(w.w, text.FunctionNotFound, pattern)
+		fmt.Fprintln(w.w)
+		return nil
+	}
+	_, hasColumns := w.r.(FunctionColumnReader)
+	for res.Next() {
+		f := res.Get()
+		if hasColumns {
+			f.ArgTypes, err = w.getFunctionColumns(f.Catalog, f.Schema, f.SpecificName)
+			if err != nil {
+				return fmt.Errorf("failed to get columns of function %s.%s: %w", f.Schema, f.SpecificName, err)
+			}
+		}
+		name := f.Name
+		if f.Schema != "" {
+			name = f.Schema + "." + name
+		}
+		sig := fmt.Sprintf("%s(%s)", name, f.ArgTypes)
+		if f.ResultType != "" {
+			sig += " RETURNS " + f.ResultType
+		}
+		fmt.Fprintln(w.w, sig)
+	}
+	return nil
+}
+
 func (w DefaultWriter) getFunctionColumns(c, s, f string) (string, error) {
 	r := w.r.(FunctionColumnReader)
 	cols, err := r.FunctionColumns(Filter{Catalog: c, Schema: s, Parent: f})
@@ -292,7 +336,14 @@ func (w DefaultWriter) encodeWithSummary(res tblfmt.ResultSet, params map[string
 
 func (w DefaultWriter) tableDetailsSummary(sp, tp string) func(io.Writer, int) (int, error) {
 	return func(out io.Writer, _ int) (int, error) {
-		err := w.describeTableIndexes(out, sp, tp)
+		partitions, err := w.getTablePartitions(sp, tp)
+		if err != nil {
+			return 0, err
+		}
+		if len(partitions) != 0 {
+			fmt.Fprintf(out, "Partition key: %s (%s)\n", partitions[0].Strategy, partitions[0].Key)
+		}
+		err = w.describeTableIndexes(out, sp, tp)
 		if err != nil {
 			return 0, err
 		}
@@ -362,10 +413,48 @@ func (w DefaultWriter) tableDetailsSummary(sp, tp string) func(io.Writer, int) (
 		if err != nil {
 			return 0, err
 		}
+		w.describeTablePartitions(out, partitions)
 		return 0, err
 	}
 }
 
+func (w DefaultWriter) getTablePartitions(sp, tp string) ([]*Partition, error) {
+	r, ok := w.r.(PartitionReader)
+	if !ok {
+		return nil, nil
+	}
+	res, err := r.Partitions(Filter{Schema: sp, Parent: tp})
+	if err != nil && err != text.ErrNotSupported {
+		return nil, fmt.Errorf("failed to list partitions for table %s: %w", tp, err)
+	}
+	if res == nil {
+		return nil, nil
+	}
+	defer res.Close()
+
+	partitions := []*Partition{}
+	for res.Next() {
+		partitions = append(partitions, res.Get())
+	}
+	return partitions, nil
+}
+
+func (w DefaultWriter) describeTablePartitions(out io.Writer, partitions []*Partition) {
+	named := []*Partition{}
+	for _, p := range partitions {
+		if p.Name != "" {
+			named = append(named, p)
+		}
+	}
+	if len(named) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "Partitions:")
+	for _, p := range named {
+		fmt.Fprintf(out, "  \"%s\" %s\n", p.Name, p.Bounds)
+	}
+}
+
 func (w DefaultWriter) describeTableTriggers(out io.Writer, sp, tp string) error {
 	r, ok := w.r.(TriggerReader)
 	if !ok {
@@ -597,6 +686,15 @@ func (w DefaultWriter) ListTables(u *dburl.URL, tableTypes, pattern string, verb
 		fmt.Fprintln(w.w)
 		return nil
 	}
+	if rr, ok := w.r.(TableRowReader); ok && verbose {
+		for res.Next() {
+			t := res.Get()
+			if t.Rows, err = rr.TableRows(*t); err != nil {
+				return fmt.Errorf("failed to count rows: %w", err)
+			}
+		}
+		res.Reset()
+	}
 	columns := []string{"Schema", "Name", "Type"}
 	if verbose {
 		columns = append(columns, "Rows", "Size", "Comment")
@@ -688,6 +786,451 @@ func (w DefaultWriter) ListIndexes(u *dburl.URL, pattern string, verbose, showSy
 	return tblfmt.EncodeAll(w.w, res, params)
 }
 
+// ListTypes matching pattern
+func (w DefaultWriter) ListTypes(u *dburl.URL, pattern string, verbose, showSystem bool) error {
+	r, ok := w.r.(TypeReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\dT`, u.Driver)
+	}
+	sp, tp, err := parsePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to parse search pattern: %w", err)
+	}
+	res, err := r.Types(Filter{Schema: sp, Name: tp, WithSystem: showSystem})
+	if err != nil {
+		return fmt.Errorf("failed to list types: %w", err)
+	}
+	defer res.Close()
+
+	if !showSystem {
+		// in case the reader doesn't implement WithSystem
+		res.SetFilter(func(r Result) bool {
+			_, ok := w.systemSchemas[r.(*Type).Schema]
+			return !ok
+		})
+	}
+
+	columns := []string{"Schema", "Name", "Kind"}
+	if verbose {
+		columns = append(columns, "Base type", "Elements", "Constraints")
+	}
+	columns = append(columns, "Description")
+	res.SetColumns(columns)
+	res.SetScanValues(func(r Result) []interface{} {
+		f := r.(*Type)
+		v := []interface{}{f.Schema, f.Name, f.Kind}
+		if verbose {
+			v = append(v, f.BaseType, strings.Join(f.Labels, "\n"), f.Constraints)
+		}
+		return append(v, f.Comment)
+	})
+
+	params := env.Pall()
+	params["title"] = "List of data types"
+	return tblfmt.EncodeAll(w.w, res, params)
+}
+
+// ListDomains matching pattern
+func (w DefaultWriter) ListDomains(u *dburl.URL, pattern string, verbose, showSystem bool) error {
+	r, ok := w.r.(TypeReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\dD`, u.Driver)
+	}
+	sp, tp, err := parsePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to parse search pattern: %w", err)
+	}
+	res, err := r.Types(Filter{Schema: sp, Name: tp, Types: []string{"DOMAIN"}, WithSystem: showSystem})
+	if err != nil {
+		return fmt.Errorf("failed to list domains: %w", err)
+	}
+	defer res.Close()
+
+	res.SetFilter(func(r Result) bool {
+		f := r.(*Type)
+		if f.Kind != "DOMAIN" {
+			// in case the reader doesn't implement filtering by Types
+			return false
+		}
+		_, ok := w.systemSchemas[f.Schema]
+		return showSystem || !ok
+	})
+
+	columns := []string{"Schema", "Name", "Type", "Constraints"}
+	if verbose {
+		columns = append(columns, "Description")
+	}
+	res.SetColumns(columns)
+	res.SetScanValues(func(r Result) []interface{} {
+		f := r.(*Type)
+		v := []interface{}{f.Schema, f.Name, f.BaseType, f.Constraints}
+		if verbose {
+			v = append(v, f.Comment)
+		}
+		return v
+	})
+
+	params := env.Pall()
+	params["title"] = "List of domains"
+	return tblfmt.EncodeAll(w.w, res, params)
+}
+
+// ListExtensions matching pattern
+func (w DefaultWriter) ListExtensions(u *dburl.URL, pattern string, verbose bool) error {
+	r, ok := w.r.(ExtensionReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\dx`, u.Driver)
+	}
+	sp, tp, err := parsePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to parse search pattern: %w", err)
+	}
+	res, err := r.Extensions(Filter{Schema: sp, Name: tp})
+	if err != nil {
+		return fmt.Errorf("failed to list extensions: %w", err)
+	}
+	defer res.Close()
+
+	if !verbose {
+		params := env.Pall()
+		params["title"] = "List of installed extensions"
+		return tblfmt.EncodeAll(w.w, res, params)
+	}
+
+	if res.Len() == 0 {
+		fmt.Fprintf(w.w, text.RelationNotFound, pattern)
+		fmt.Fprintln(w.w)
+		return nil
+	}
+	or, ok := w.r.(ExtensionObjectReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\dx+`, u.Driver)
+	}
+	for res.Next() {
+		e := res.Get()
+		objects, err := or.ExtensionObjects(Filter{Parent: e.Name})
+		if err != nil {
+			return fmt.Errorf("failed to list extension objects: %w", err)
+		}
+		objects.SetColumns([]string{"Object description"})
+		objects.SetScanValues(func(r Result) []interface{} {
+			return []interface{}{r.(*ExtensionObject).Description}
+		})
+		params := env.Pall()
+		params["title"] = fmt.Sprintf("Objects in extension %q", e.Name)
+		err = tblfmt.EncodeAll(w.w, objects, params)
+		objects.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListForeignTables matching pattern
+func (w DefaultWriter) ListForeignTables(u *dburl.URL, pattern string, verbose, showSystem bool) error {
+	r, ok := w.r.(ForeignTableReader)
+	if !ok {
+		return w.ListTables(u, "E", pattern, verbose, showSystem)
+	}
+	sp, tp, err := parsePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to parse search pattern: %w", err)
+	}
+	res, err := r.ForeignTables(Filter{Schema: sp, Name: tp, WithSystem: showSystem})
+	if err != nil {
+		return fmt.Errorf("failed to list foreign tables: %w", err)
+	}
+	defer res.Close()
+
+	if !showSystem {
+		// in case the reader doesn't implement WithSystem
+		res.SetFilter(func(r Result) bool {
+			_, ok := w.systemSchemas[r.(*ForeignTable).Schema]
+			return !ok
+		})
+	}
+	if res.Len() == 0 {
+		fmt.Fprintf(w.w, text.RelationNotFound, pattern)
+		fmt.Fprintln(w.w)
+		return nil
+	}
+
+	columns := []string{"Schema", "Name", "Server"}
+	if verbose {
+		columns = append(columns, "FDW options", "Description")
+	}
+	res.SetColumns(columns)
+	res.SetScanValues(func(r Result) []interface{} {
+		f := r.(*ForeignTable)
+		v := []interface{}{f.Schema, f.Name, f.Server}
+		if verbose {
+			v = append(v, f.Options, f.Comment)
+		}
+		return v
+	})
+
+	params := env.Pall()
+	params["title"] = "List of foreign tables"
+	return tblfmt.EncodeAll(w.w, res, params)
+}
+
+// ListForeignServers matching pattern
+func (w DefaultWriter) ListForeignServers(u *dburl.URL, pattern string, verbose bool) error {
+	r, ok := w.r.(ForeignServerReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\des`, u.Driver)
+	}
+	res, err := r.ForeignServers(Filter{Name: strings.ReplaceAll(pattern, "*", "%")})
+	if err != nil {
+		return fmt.Errorf("failed to list foreign servers: %w", err)
+	}
+	defer res.Close()
+
+	columns := []string{"Name", "Owner", "Foreign-data wrapper"}
+	if verbose {
+		columns = append(columns, "Type", "Version", "FDW options", "Description")
+	}
+	res.SetColumns(columns)
+	res.SetScanValues(func(r Result) []interface{} {
+		f := r.(*ForeignServer)
+		v := []interface{}{f.Name, f.Owner, f.Wrapper}
+		if verbose {
+			v = append(v, f.Type, f.Version, f.Options, f.Comment)
+		}
+		return v
+	})
+
+	params := env.Pall()
+	params["title"] = "List of foreign servers"
+	return tblfmt.EncodeAll(w.w, res, params)
+}
+
+// ListForeignDataWrappers matching pattern
+func (w DefaultWriter) ListForeignDataWrappers(u *dburl.URL, pattern string, verbose bool) error {
+	r, ok := w.r.(ForeignDataWrapperReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\dew`, u.Driver)
+	}
+	res, err := r.ForeignDataWrappers(Filter{Name: strings.ReplaceAll(pattern, "*", "%")})
+	if err != nil {
+		return fmt.Errorf("failed to list foreign-data wrappers: %w", err)
+	}
+	defer res.Close()
+
+	columns := []string{"Name", "Owner", "Handler", "Validator"}
+	if verbose {
+		columns = append(columns, "FDW options", "Description")
+	}
+	res.SetColumns(columns)
+	res.SetScanValues(func(r Result) []interface{} {
+		f := r.(*ForeignDataWrapper)
+		v := []interface{}{f.Name, f.Owner, f.Handler, f.Validator}
+		if verbose {
+			v = append(v, f.Options, f.Comment)
+		}
+		return v
+	})
+
+	params := env.Pall()
+	params["title"] = "List of foreign-data wrappers"
+	return tblfmt.EncodeAll(w.w, res, params)
+}
+
+// ListUserMappings matching pattern
+func (w DefaultWriter) ListUserMappings(u *dburl.URL, pattern string, verbose bool) error {
+	r, ok := w.r.(UserMappingReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\deu`, u.Driver)
+	}
+	res, err := r.UserMappings(Filter{Name: strings.ReplaceAll(pattern, "*", "%")})
+	if err != nil {
+		return fmt.Errorf("failed to list user mappings: %w", err)
+	}
+	defer res.Close()
+
+	columns := []string{"Server", "User name"}
+	if verbose {
+		columns = append(columns, "FDW options")
+	}
+	res.SetColumns(columns)
+	res.SetScanValues(func(r Result) []interface{} {
+		f := r.(*UserMapping)
+		v := []interface{}{f.Server, f.User}
+		if verbose {
+			v = append(v, f.Options)
+		}
+		return v
+	})
+
+	params := env.Pall()
+	params["title"] = "List of user mappings"
+	return tblfmt.EncodeAll(w.w, res, params)
+}
+
+// ListTriggers matching pattern
+func (w DefaultWriter) ListTriggers(u *dburl.URL, pattern string, verbose, showSystem bool) error {
+	r, ok := w.r.(TriggerReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\dy`, u.Driver)
+	}
+	sp, tp, err := parsePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to parse search pattern: %w", err)
+	}
+	res, err := r.Triggers(Filter{Schema: sp, Name: tp, WithSystem: showSystem})
+	if err != nil {
+		return fmt.Errorf("failed to list triggers: %w", err)
+	}
+	defer res.Close()
+
+	if !showSystem {
+		// in case the reader doesn't implement WithSystem
+		res.SetFilter(func(r Result) bool {
+			_, ok := w.systemSchemas[r.(*Trigger).Schema]
+			return !ok
+		})
+	}
+
+	columns := []string{"Schema", "Name", "Table", "Timing", "Event", "Enabled", "Function"}
+	if verbose {
+		columns = append(columns, "Definition")
+	}
+	res.SetColumns(columns)
+	res.SetScanValues(func(r Result) []interface{} {
+		f := r.(*Trigger)
+		v := []interface{}{f.Schema, f.Name, f.Table, f.Timing, f.Event, f.Enabled, f.Function}
+		if verbose {
+			v = append(v, f.Definition)
+		}
+		return v
+	})
+
+	params := env.Pall()
+	params["title"] = "List of triggers"
+	return tblfmt.EncodeAll(w.w, res, params)
+}
+
+// ListConstraints matching pattern
+func (w DefaultWriter) ListConstraints(u *dburl.URL, pattern string, verbose, showSystem bool) error {
+	r, ok := w.r.(ConstraintReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\dco`, u.Driver)
+	}
+	sp, tp, err := parsePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to parse search pattern: %w", err)
+	}
+	res, err := r.Constraints(Filter{Schema: sp, Name: tp, WithSystem: showSystem})
+	if err != nil {
+		return fmt.Errorf("failed to list constraints: %w", err)
+	}
+	defer res.Close()
+
+	if !showSystem {
+		// in case the reader doesn't implement WithSystem
+		res.SetFilter(func(r Result) bool {
+			_, ok := w.systemSchemas[r.(*Constraint).Schema]
+			return !ok
+		})
+	}
+
+	_, hasColumns := w.r.(ConstraintColumnReader)
+	type constraintColumns struct {
+		columns, foreignColumns string
+	}
+	constraintCols := map[*Constraint]constraintColumns{}
+	if hasColumns {
+		for res.Next() {
+			f := res.Get()
+			cols, foreignCols, err := w.getConstraintColumns(f.Catalog, f.Schema, f.Table, f.Name)
+			if err == text.ErrNotSupported {
+				hasColumns = false
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get columns of constraint %s: %w", f.Name, err)
+			}
+			constraintCols[f] = constraintColumns{cols, foreignCols}
+		}
+		res.Reset()
+	}
+	columns := []string{"Schema", "Name", "Type", "Table"}
+	if hasColumns {
+		columns = append(columns, "Columns")
+	}
+	columns = append(columns, "Referenced table")
+	if hasColumns {
+		columns = append(columns, "Referenced columns")
+	}
+	columns = append(columns, "On update", "On delete")
+	if verbose {
+		columns = append(columns, "Check clause", "Deferrable?", "Initially deferred?")
+	}
+	res.SetColumns(columns)
+	res.SetScanValues(func(r Result) []interface{} {
+		f := r.(*Constraint)
+		foreignTable := f.ForeignTable
+		if foreignTable != "" && f.ForeignSchema != "" {
+			foreignTable = f.ForeignSchema + "." + foreignTable
+		}
+		v := []interface{}{f.Schema, f.Name, f.Type, f.Table}
+		if hasColumns {
+			cols := constraintCols[f]
+			if foreignTable == "" {
+				cols.foreignColumns = ""
+			}
+			v = append(v, cols.columns, foreignTable, cols.foreignColumns)
+		} else {
+			v = append(v, foreignTable)
+		}
+		v = append(v, f.UpdateRule, f.DeleteRule)
+		if verbose {
+			v = append(v, f.CheckClause, f.IsDeferrable, f.IsInitiallyDeferred)
+		}
+		return v
+	})
+
+	params := env.Pall()
+	params["title"] = "List of constraints"
+	return tblfmt.EncodeAll(w.w, res, params)
+}
+
+// ListPartitions of tables matching pattern
+func (w DefaultWriter) ListPartitions(u *dburl.URL, pattern string, verbose bool) error {
+	r, ok := w.r.(PartitionReader)
+	if !ok {
+		return fmt.Errorf(text.NotSupportedByDriver, `\dP`, u.Driver)
+	}
+	sp, tp, err := parsePattern(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to parse search pattern: %w", err)
+	}
+	res, err := r.Partitions(Filter{Schema: sp, Parent: tp})
+	if err != nil {
+		return fmt.Errorf("failed to list partitions: %w", err)
+	}
+	defer res.Close()
+
+	columns := []string{"Schema", "Table", "Name", "Strategy", "Key", "Bounds"}
+	if verbose {
+		columns = append(columns, "Rows", "Size")
+	}
+	res.SetColumns(columns)
+	res.SetScanValues(func(r Result) []interface{} {
+		f := r.(*Partition)
+		v := []interface{}{f.Schema, f.Table, f.Name, f.Strategy, f.Key, f.Bounds}
+		if verbose {
+			v = append(v, f.Rows, f.Size)
+		}
+		return v
+	})
+
+	params := env.Pall()
+	params["title"] = "List of partitions"
+	return tblfmt.EncodeAll(w.w, res, params)
+}
+
 // ShowStats of columns for tables matching pattern
 func (w DefaultWriter) ShowStats(u *dburl.URL, statTypes, pattern string, verbose bool, k int) error {
 	r, ok := w.r.(ColumnStatReader)
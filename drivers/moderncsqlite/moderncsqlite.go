@@ -12,6 +12,7 @@ import (
 
 	"github.com/xo/dburl"
 	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/plan"
 	"github.com/xo/usql/drivers/sqlite3/sqshared"
 	"modernc.org/sqlite" // DRIVER
 )
@@ -19,6 +20,9 @@ import (
 func init() {
 	drivers.Register("moderncsqlite", drivers.Driver{
 		AllowMultilineComments: true,
+		AllowBackticks:         true,
+		AllowBrackets:          true,
+		AllowBlocks:            true,
 		Open: func(_ context.Context, u *dburl.URL, stdout, stderr func() io.Writer) (func(string, string) (*sql.DB, error), error) {
 			return func(_ string, params string) (*sql.DB, error) {
 				return sql.Open("sqlite", params)
@@ -41,5 +45,6 @@ func init() {
 		ConvertBytes:      sqshared.ConvertBytes,
 		NewMetadataReader: sqshared.NewMetadataReader,
 		Copy:              drivers.CopyWithInsert(func(int) string { return "?" }),
+		Explain:           plan.SQLite,
 	})
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/xo/usql/drivers"
 	"github.com/xo/usql/drivers/metadata"
 	mymeta "github.com/xo/usql/drivers/metadata/mysql"
+	"github.com/xo/usql/drivers/mysql/myshared"
+	"github.com/xo/usql/drivers/plan"
 	_ "github.com/ziutek/mymysql/godrv" // DRIVER
 	"github.com/ziutek/mymysql/mysql"
 )
@@ -18,6 +20,10 @@ func init() {
 	drivers.Register("mymysql", drivers.Driver{
 		AllowMultilineComments: true,
 		AllowHashComments:      true,
+		AllowBackslashEscapes:  true,
+		AllowBackticks:         true,
+		AllowDelimiter:         true,
+		AllowBlocks:            true,
 		LexerName:              "mysql",
 		UseColumnTypes:         true,
 		Err: func(err error) (string, string) {
@@ -38,5 +44,8 @@ func init() {
 		},
 		Copy:         drivers.CopyWithInsert(func(int) string { return "?" }),
 		NewCompleter: mymeta.NewCompleter,
+		Dialect:      mymeta.Dialect,
+		Explain:      plan.MySQL,
+		Warnings:     myshared.Warnings,
 	})
 }
@@ -0,0 +1,37 @@
+// Package myshared contains shared code for the mysql and mymysql drivers.
+package myshared
+
+import (
+	"context"
+
+	"github.com/xo/usql/drivers"
+)
+
+// Warnings retrieves the warning count and the warnings raised by the last
+// executed statement.
+func Warnings(ctx context.Context, db drivers.DB) (int64, []drivers.Warning, error) {
+	var count int64
+	if err := db.QueryRowContext(ctx, `SHOW COUNT(*) WARNINGS`).Scan(&count); err != nil {
+		return 0, nil, err
+	}
+	if count == 0 {
+		return 0, nil, nil
+	}
+	rows, err := db.QueryContext(ctx, `SHOW WARNINGS`)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+	var warnings []drivers.Warning
+	for rows.Next() {
+		var w drivers.Warning
+		if err := rows.Scan(&w.Level, &w.Code, &w.Message); err != nil {
+			return 0, nil, err
+		}
+		warnings = append(warnings, w)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, nil, err
+	}
+	return count, warnings, nil
+}
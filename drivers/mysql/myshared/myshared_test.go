@@ -0,0 +1,58 @@
+package myshared
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestWarnings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not create stub database: %v", err)
+	}
+	defer db.Close()
+	mock.ExpectQuery(`SHOW COUNT\(\*\) WARNINGS`).
+		WillReturnRows(sqlmock.NewRows([]string{"@@session.warning_count"}).AddRow(2))
+	mock.ExpectQuery(`SHOW WARNINGS`).
+		WillReturnRows(sqlmock.NewRows([]string{"Level", "Code", "Message"}).
+			AddRow("Warning", 1265, "Data truncated for column 'name' at row 1").
+			AddRow("Note", 1051, "Unknown table 'test.missing'"))
+	count, warnings, err := Warnings(context.Background(), db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if count != 2 || len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got: %d %v", count, warnings)
+	}
+	if w := warnings[0]; w.Level != "Warning" || w.Code != 1265 || w.Message != "Data truncated for column 'name' at row 1" {
+		t.Errorf("expected data truncated warning, got: %+v", w)
+	}
+	if w := warnings[1]; w.Level != "Note" || w.Code != 1051 {
+		t.Errorf("expected unknown table note, got: %+v", w)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWarningsNone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not create stub database: %v", err)
+	}
+	defer db.Close()
+	mock.ExpectQuery(`SHOW COUNT\(\*\) WARNINGS`).
+		WillReturnRows(sqlmock.NewRows([]string{"@@session.warning_count"}).AddRow(0))
+	count, warnings, err := Warnings(context.Background(), db)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if count != 0 || len(warnings) != 0 {
+		t.Errorf("expected no warnings, got: %d %v", count, warnings)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -16,12 +16,18 @@ import (
 	"github.com/xo/usql/drivers"
 	"github.com/xo/usql/drivers/metadata"
 	mymeta "github.com/xo/usql/drivers/metadata/mysql"
+	"github.com/xo/usql/drivers/mysql/myshared"
+	"github.com/xo/usql/drivers/plan"
 )
 
 func init() {
 	drivers.Register("mysql", drivers.Driver{
 		AllowMultilineComments: true,
 		AllowHashComments:      true,
+		AllowBackslashEscapes:  true,
+		AllowBackticks:         true,
+		AllowDelimiter:         true,
+		AllowBlocks:            true,
 		LexerName:              "mysql",
 		UseColumnTypes:         true,
 		ForceParams: drivers.ForceQueryParameters([]string{
@@ -47,5 +53,8 @@ func init() {
 		},
 		Copy:         drivers.CopyWithInsert(func(int) string { return "?" }),
 		NewCompleter: mymeta.NewCompleter,
+		Dialect:      mymeta.Dialect,
+		Explain:      plan.MySQL,
+		Warnings:     myshared.Warnings,
 	}, "memsql", "vitess", "tidb")
 }
@@ -4,6 +4,7 @@ package orshared
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"io"
 	"regexp"
@@ -14,6 +15,7 @@ import (
 	"github.com/xo/usql/drivers/metadata"
 	orameta "github.com/xo/usql/drivers/metadata/oracle"
 	"github.com/xo/usql/env"
+	"github.com/xo/usql/stmt"
 )
 
 // Register registers an oracle driver.
@@ -22,6 +24,8 @@ func Register(name string, err func(error) (string, string), isPasswordErr func(
 	endAnchorRE := regexp.MustCompile(`(?i)\send\s*;\s*$`)
 	drivers.Register(name, drivers.Driver{
 		AllowMultilineComments: true,
+		AllowQQuotes:           true,
+		AllowPLSQL:             true,
 		LowerColumnNames:       true,
 		ForceParams: func(u *dburl.URL) {
 			// if the service name is not specified, use the environment
@@ -56,8 +60,8 @@ func Register(name string, err func(error) (string, string), isPasswordErr func(
 		Err:           err,
 		IsPasswordErr: isPasswordErr,
 		Process: func(prefix string, sqlstr string) (string, string, bool, error) {
-			if !endAnchorRE.MatchString(sqlstr) {
-				// trim last ; but only when not END;
+			if !endAnchorRE.MatchString(sqlstr) && !stmt.IsPLSQLBlock(prefix) {
+				// trim last ; but only when not END; or a PL/SQL block
 				sqlstr = endRE.ReplaceAllString(sqlstr, "")
 			}
 			typ, q := drivers.QueryExecType(prefix, sqlstr)
@@ -70,5 +74,61 @@ func Register(name string, err func(error) (string, string), isPasswordErr func(
 		Copy: drivers.CopyWithInsert(func(n int) string {
 			return fmt.Sprintf(":%d", n)
 		}),
+		Setup: func(ctx context.Context, db drivers.DB) error {
+			if env.All()["SERVEROUTPUT"] != "on" {
+				return nil
+			}
+			_, err := db.ExecContext(ctx, enableOutput)
+			return err
+		},
+		Output: func(ctx context.Context, db drivers.DB, w io.Writer) error {
+			if env.All()["SERVEROUTPUT"] != "on" {
+				return nil
+			}
+			return writeOutput(ctx, db, w)
+		},
 	})
 }
+
+// enableOutput enables DBMS_OUTPUT for the session, without a buffer limit.
+// Sessions are prepared before each statement, as the connection executing
+// it may not have been used before.
+const enableOutput = `BEGIN DBMS_OUTPUT.ENABLE(NULL); END;`
+
+// getLines retrieves buffered DBMS_OUTPUT lines. Lines are joined, as not all
+// drivers can bind DBMS_OUTPUT.CHARARR, until they no longer fit in a
+// VARCHAR2, returning the line that did not fit separately.
+const getLines = `DECLARE
+  line VARCHAR2(32767);
+  status INTEGER;
+  buf VARCHAR2(32767);
+BEGIN
+  LOOP
+    DBMS_OUTPUT.GET_LINE(line, status);
+    EXIT WHEN status <> 0 OR NVL(LENGTHB(buf), 0) + NVL(LENGTHB(line), 0) + 1 > 32767;
+    buf := buf || line || CHR(10);
+  END LOOP;
+  :1 := buf;
+  :2 := line;
+  :3 := status;
+END;`
+
+// writeOutput drains the session's DBMS_OUTPUT buffer, writing the lines to w.
+func writeOutput(ctx context.Context, db drivers.DB, w io.Writer) error {
+	for {
+		// size out parameters for the largest VARCHAR2
+		buf, line, status := strings.Repeat(" ", 32767), strings.Repeat(" ", 32767), 0
+		if _, err := db.ExecContext(ctx, getLines, sql.Out{Dest: &buf}, sql.Out{Dest: &line}, sql.Out{Dest: &status}); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, buf); err != nil {
+			return err
+		}
+		if status != 0 {
+			return nil
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package orshared
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xo/usql/drivers"
+)
+
+// stubDB is a database returning DBMS_OUTPUT lines, joined as by getLines.
+type stubDB struct {
+	drivers.DB
+	lines []string
+	execs int
+}
+
+func (db *stubDB) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	db.execs++
+	buf, line, status := args[0].(sql.Out).Dest.(*string), args[1].(sql.Out).Dest.(*string), args[2].(sql.Out).Dest.(*int)
+	if len(*buf) != 32767 || len(*line) != 32767 {
+		return nil, errors.New("out parameters are not sized for a VARCHAR2")
+	}
+	*buf, *line, *status = "", "", 1
+	for len(db.lines) != 0 {
+		*line, *status, db.lines = db.lines[0], 0, db.lines[1:]
+		if len(*buf)+len(*line)+1 > 32767 {
+			return nil, nil
+		}
+		*buf += *line + "\n"
+	}
+	*line, *status = "", 1
+	return nil, nil
+}
+
+func TestWriteOutput(t *testing.T) {
+	long := strings.Repeat("a", 20000)
+	tests := []struct {
+		lines []string
+		execs int
+	}{
+		{nil, 1},
+		{[]string{"one", "", "two"}, 1},
+		{[]string{long, long, "end"}, 2},
+		{[]string{long, strings.Repeat("b", 32767), long}, 2},
+	}
+	for i, test := range tests {
+		db := &stubDB{lines: test.lines}
+		var w bytes.Buffer
+		if err := writeOutput(context.Background(), db, &w); err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		exp := ""
+		for _, line := range test.lines {
+			exp += line + "\n"
+		}
+		if s := w.String(); s != exp {
+			t.Errorf("test %d expected %d bytes of output, got: %d", i, len(exp), len(s))
+		}
+		if db.execs != test.execs {
+			t.Errorf("test %d expected %d executions, got: %d", i, test.execs, db.execs)
+		}
+	}
+}
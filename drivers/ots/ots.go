@@ -9,5 +9,8 @@ import (
 )
 
 func init() {
-	drivers.Register("ots", drivers.Driver{})
+	drivers.Register("ots", drivers.Driver{
+		AllowBackslashEscapes: true,
+		AllowBackticks:        true,
+	})
 }
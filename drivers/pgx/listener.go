@@ -0,0 +1,60 @@
+package pgx
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/xo/dburl"
+	"github.com/xo/usql/drivers"
+)
+
+// listener is a dedicated connection listening for notifications.
+type listener struct {
+	conn *pgx.Conn
+}
+
+// newListener creates a new listener.
+func newListener(ctx context.Context, u *dburl.URL, _ *sql.DB) (drivers.Listener, error) {
+	conn, err := pgx.Connect(ctx, u.DSN)
+	if err != nil {
+		return nil, err
+	}
+	return &listener{
+		conn: conn,
+	}, nil
+}
+
+// Listen satisfies the drivers.Listener interface.
+func (l *listener) Listen(ctx context.Context, channel string) error {
+	_, err := l.conn.Exec(ctx, `LISTEN `+pgx.Identifier{channel}.Sanitize())
+	return err
+}
+
+// Unlisten satisfies the drivers.Listener interface.
+func (l *listener) Unlisten(ctx context.Context, channel string) error {
+	sqlstr := `UNLISTEN *`
+	if channel != "" {
+		sqlstr = `UNLISTEN ` + pgx.Identifier{channel}.Sanitize()
+	}
+	_, err := l.conn.Exec(ctx, sqlstr)
+	return err
+}
+
+// Wait satisfies the drivers.Listener interface.
+func (l *listener) Wait(ctx context.Context) (*drivers.Notification, error) {
+	n, err := l.conn.WaitForNotification(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &drivers.Notification{
+		Channel: n.Channel,
+		Payload: n.Payload,
+		PID:     int(n.PID),
+	}, nil
+}
+
+// Close satisfies the drivers.Listener interface.
+func (l *listener) Close() error {
+	return l.conn.Close(context.Background())
+}
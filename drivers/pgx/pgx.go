@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/jackc/pgconn"
@@ -17,12 +18,14 @@ import (
 	"github.com/xo/usql/drivers"
 	"github.com/xo/usql/drivers/metadata"
 	pgmeta "github.com/xo/usql/drivers/metadata/postgres"
+	"github.com/xo/usql/drivers/plan"
 )
 
 func init() {
 	drivers.Register("pgx", drivers.Driver{
 		AllowDollar:            true,
 		AllowMultilineComments: true,
+		AllowEStrings:          true,
 		LexerName:              "postgres",
 		Version: func(ctx context.Context, db drivers.DB) (string, error) {
 			var ver string
@@ -51,9 +54,13 @@ func init() {
 			return false
 		},
 		NewMetadataReader: pgmeta.NewReader(),
+		Dialect:           pgmeta.Dialect,
 		NewMetadataWriter: func(db drivers.DB, w io.Writer, opts ...metadata.ReaderOption) metadata.Writer {
 			return metadata.NewDefaultWriter(pgmeta.NewReader()(db, opts...))(db, w)
 		},
+		NewListener: newListener,
+		Explain:     plan.Postgres,
+		CopyIO:      copyIO,
 		Copy: func(ctx context.Context, db *sql.DB, rows *sql.Rows, table string) (int64, error) {
 			conn, err := db.Conn(context.Background())
 			if err != nil {
@@ -101,6 +108,36 @@ func init() {
 	})
 }
 
+// copyOutRE matches COPY statements writing to standard output.
+var copyOutRE = regexp.MustCompile(`(?is)\bTO\s+STDOUT\b`)
+
+// copyIO passes COPY ... TO STDOUT and COPY ... FROM STDIN statements through
+// to the underlying connection.
+func copyIO(ctx context.Context, db drivers.DB, sqlstr string, r io.Reader, w io.Writer) (int64, error) {
+	sqldb, ok := db.(*sql.DB)
+	if !ok {
+		return 0, errors.New("COPY FROM STDIN or TO STDOUT is not supported within a transaction")
+	}
+	conn, err := sqldb.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get a connection from pool: %w", err)
+	}
+	defer conn.Close()
+	var n int64
+	err = conn.Raw(func(driverConn interface{}) error {
+		conn := driverConn.(*stdlib.Conn).Conn().PgConn()
+		if copyOutRE.MatchString(sqlstr) {
+			tag, err := conn.CopyTo(ctx, w, sqlstr)
+			n = tag.RowsAffected()
+			return err
+		}
+		tag, err := conn.CopyFrom(ctx, r, sqlstr)
+		n = tag.RowsAffected()
+		return err
+	})
+	return n, err
+}
+
 type copyRows struct {
 	rows   *sql.Rows
 	values []interface{}
@@ -0,0 +1,214 @@
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MySQL explains a query using MySQL's EXPLAIN FORMAT=JSON, or EXPLAIN
+// ANALYZE (which is only available in the tree format) when analyzing.
+func MySQL(ctx context.Context, db DB, sqlstr string, analyze bool) (*Node, error) {
+	q := `EXPLAIN FORMAT=JSON `
+	if analyze {
+		q = `EXPLAIN ANALYZE `
+	}
+	var buf []byte
+	if err := db.QueryRowContext(ctx, q+sqlstr).Scan(&buf); err != nil {
+		return nil, err
+	}
+	if analyze {
+		return ParseMySQLTree(string(buf))
+	}
+	return ParseMySQL(buf)
+}
+
+// ParseMySQL parses a MySQL JSON plan.
+func ParseMySQL(buf []byte) (*Node, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(buf, &m); err != nil {
+		return nil, err
+	}
+	nodes := mysqlNodes(m)
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("empty plan")
+	}
+	return nodes[0], nil
+}
+
+// mysqlOps are the MySQL JSON plan operations, and their names.
+var mysqlOps = map[string]string{
+	"query_block":                "Query Block",
+	"nested_loop":                "Nested Loop",
+	"ordering_operation":         "Sort",
+	"grouping_operation":         "Group",
+	"duplicates_removal":         "Distinct",
+	"windowing":                  "Window",
+	"union_result":               "Union",
+	"materialized_from_subquery": "Materialize",
+	"buffer_result":              "Buffer",
+}
+
+// mysqlAccessTypes are the descriptions of MySQL access types.
+var mysqlAccessTypes = map[string]string{
+	"ALL":             "Table Scan",
+	"index":           "Index Scan",
+	"range":           "Index Range Scan",
+	"ref":             "Index Lookup",
+	"eq_ref":          "Unique Index Lookup",
+	"ref_or_null":     "Index Lookup",
+	"const":           "Constant Lookup",
+	"system":          "Constant Lookup",
+	"fulltext":        "Fulltext Index Lookup",
+	"index_merge":     "Index Merge",
+	"unique_subquery": "Unique Subquery",
+	"index_subquery":  "Index Subquery",
+}
+
+// mysqlNodes returns the plan nodes found in the JSON value v.
+func mysqlNodes(v interface{}) []*Node {
+	switch x := v.(type) {
+	case []interface{}:
+		var nodes []*Node
+		for _, z := range x {
+			nodes = append(nodes, mysqlNodes(z)...)
+		}
+		return nodes
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var nodes []*Node
+		for _, k := range keys {
+			switch op, ok := mysqlOps[k]; {
+			case k == "table":
+				if m, ok := x[k].(map[string]interface{}); ok {
+					nodes = append(nodes, mysqlTable(m))
+				}
+			case ok:
+				n := &Node{
+					Op:       op,
+					Children: mysqlNodes(x[k]),
+				}
+				if m, ok := x[k].(map[string]interface{}); ok {
+					if c, ok := m["cost_info"].(map[string]interface{}); ok {
+						n.Cost = toFloat(c["query_cost"]) + toFloat(c["sort_cost"])
+					}
+					if b, _ := m["using_filesort"].(bool); b {
+						n.Details = append(n.Details, "Using filesort")
+					}
+					if b, _ := m["using_temporary_table"].(bool); b {
+						n.Details = append(n.Details, "Using temporary table")
+					}
+				}
+				// use the cost of the children when not available
+				if n.Cost == 0 {
+					for _, c := range n.Children {
+						n.Cost += c.Cost
+					}
+				}
+				nodes = append(nodes, n)
+			default:
+				nodes = append(nodes, mysqlNodes(x[k])...)
+			}
+		}
+		return nodes
+	}
+	return nil
+}
+
+// mysqlTable converts a MySQL JSON plan table to a node.
+func mysqlTable(m map[string]interface{}) *Node {
+	typ, _ := m["access_type"].(string)
+	op, ok := mysqlAccessTypes[typ]
+	if !ok {
+		op = "Table Access"
+	}
+	n := &Node{
+		Op:       op,
+		Rows:     toFloat(m["rows_produced_per_join"]),
+		Scanned:  toFloat(m["rows_examined_per_scan"]),
+		FullScan: typ == "ALL",
+	}
+	n.Relation, _ = m["table_name"].(string)
+	n.Index, _ = m["key"].(string)
+	if c, ok := m["cost_info"].(map[string]interface{}); ok {
+		n.Cost = toFloat(c["read_cost"]) + toFloat(c["eval_cost"])
+	}
+	if s, ok := m["attached_condition"].(string); ok {
+		n.Details = append(n.Details, "Filter: "+s)
+	}
+	// subqueries and derived tables
+	sub := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k != "cost_info" {
+			sub[k] = v
+		}
+	}
+	n.Children = mysqlNodes(sub)
+	return n
+}
+
+// mysqlTreeRE matches a line of a MySQL tree plan.
+var mysqlTreeRE = regexp.MustCompile(`^( *)-> (.*?)(?:\s+\(cost=(?:[0-9.e+]+\.\.)?([0-9.e+]+) rows=([0-9.e+]+)\))?(?:\s+\(actual time=[0-9.e+]+\.\.([0-9.e+]+) rows=([0-9.e+]+) loops=([0-9]+)\)|\s+\(never executed\))?$`)
+
+// mysqlRelationRE matches the table and index of a MySQL tree plan operation.
+var mysqlRelationRE = regexp.MustCompile(`^(.*?) on (\S+)(?: using (\S+))?(?: \((.*)\))?(?: over .*)?$`)
+
+// ParseMySQLTree parses a MySQL tree plan, as returned by EXPLAIN ANALYZE.
+func ParseMySQLTree(s string) (*Node, error) {
+	var root *Node
+	var stack []*Node
+	var indents []int
+	for _, line := range strings.Split(s, "\n") {
+		m := mysqlTreeRE.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		n := &Node{
+			Op: m[2],
+		}
+		if i := strings.Index(n.Op, ": "); i != -1 {
+			n.Op, n.Details = n.Op[:i], []string{n.Op[:i] + ": " + n.Op[i+2:]}
+		} else if r := mysqlRelationRE.FindStringSubmatch(n.Op); r != nil {
+			n.Op, n.Relation, n.Index = r[1], r[2], r[3]
+			if r[4] != "" {
+				n.Details = []string{"Cond: " + r[4]}
+			}
+		}
+		n.FullScan = n.Op == "Table scan"
+		n.Cost, _ = strconv.ParseFloat(m[3], 64)
+		n.Rows, _ = strconv.ParseFloat(m[4], 64)
+		if m[5] != "" || strings.HasSuffix(line, "(never executed)") {
+			n.Analyzed = true
+			n.ActualTime, _ = strconv.ParseFloat(m[5], 64)
+			n.ActualRows, _ = strconv.ParseFloat(m[6], 64)
+			n.Loops, _ = strconv.ParseFloat(m[7], 64)
+		}
+		// pop to parent
+		indent := len(m[1])
+		for len(indents) != 0 && indents[len(indents)-1] >= indent {
+			stack, indents = stack[:len(stack)-1], indents[:len(indents)-1]
+		}
+		switch {
+		case len(stack) != 0:
+			p := stack[len(stack)-1]
+			p.Children = append(p.Children, n)
+		case root == nil:
+			root = n
+		default:
+			continue
+		}
+		stack, indents = append(stack, n), append(indents, indent)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("empty plan")
+	}
+	return root, nil
+}
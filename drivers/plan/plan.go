@@ -0,0 +1,233 @@
+// Package plan provides a common representation of query plans, and renders
+// them as a tree.
+package plan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DB is the common interface for database operations used to explain a query.
+type DB interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+// Node is a query plan node.
+type Node struct {
+	// Op is the operation (ie, Seq Scan, Hash Join).
+	Op string
+	// Relation is the table read by the operation, if any.
+	Relation string
+	// Index is the index used by the operation, if any.
+	Index string
+	// Details are additional details of the operation (ie, filters and
+	// conditions).
+	Details []string
+	// Cost is the estimated total cost of the operation, including its
+	// children.
+	Cost float64
+	// Rows is the estimated number of rows returned by the operation.
+	Rows float64
+	// Scanned is the estimated or actual number of rows read by a scan.
+	Scanned float64
+	// FullScan indicates the operation reads the entire table.
+	FullScan bool
+	// Analyzed indicates the query was executed, and actual values are
+	// available.
+	Analyzed bool
+	// ActualRows is the actual number of rows returned by the operation, per
+	// loop.
+	ActualRows float64
+	// ActualTime is the actual total time in milliseconds spent in the
+	// operation, including its children, per loop.
+	ActualTime float64
+	// Loops is the number of times the operation was executed.
+	Loops float64
+	// Children are the child operations.
+	Children []*Node
+}
+
+// Title returns the title of the node.
+func (n *Node) Title() string {
+	s := n.Op
+	if n.Index != "" {
+		s += " using " + n.Index
+	}
+	if n.Relation != "" {
+		s += " on " + n.Relation
+	}
+	return s
+}
+
+// Thresholds for highlighting nodes.
+const (
+	// expensiveShare is the share of the total cost (or time) above which a
+	// node is highlighted as expensive.
+	expensiveShare = 0.25
+	// estimateMiss is the factor between estimated and actual rows above
+	// which a node is highlighted as a row estimate miss.
+	estimateMiss = 10
+	// largeTable is the number of rows above which a full scan is
+	// highlighted.
+	largeTable = 10000
+)
+
+// Render writes the plan as an indented tree to w, highlighting the most
+// expensive nodes, row estimate misses and full scans of large tables.
+func Render(w io.Writer, root *Node) error {
+	r := &renderer{
+		w:     w,
+		costs: make(map[*Node]float64),
+	}
+	r.total = r.self(root)
+	r.render(root, 0, false)
+	return r.err
+}
+
+// renderer renders a plan.
+type renderer struct {
+	w     io.Writer
+	costs map[*Node]float64
+	total float64
+	count int
+	err   error
+}
+
+// self calculates the exclusive cost (or time, when analyzed) of n and its
+// children, returning the total.
+func (r *renderer) self(n *Node) float64 {
+	r.count++
+	cost, total := n.Cost, 0.0
+	if n.Analyzed {
+		cost = n.ActualTime * loops(n)
+	}
+	for _, c := range n.Children {
+		total += r.self(c)
+		if n.Analyzed {
+			cost -= c.ActualTime * loops(c)
+		} else {
+			cost -= c.Cost
+		}
+	}
+	if cost < 0 {
+		cost = 0
+	}
+	r.costs[n] = cost
+	return total + cost
+}
+
+// render renders n and its children, with the title of n starting at col.
+func (r *renderer) render(n *Node, col int, child bool) {
+	line := strings.Repeat(" ", col)
+	if child {
+		line, col = line+"->  ", col+4
+	}
+	line += n.Title()
+	if n.Cost != 0 || n.Rows != 0 {
+		line += fmt.Sprintf("  (cost=%s rows=%s)", format(n.Cost), format(n.Rows))
+	}
+	if n.Analyzed {
+		if n.Loops == 0 {
+			line += "  (never executed)"
+		} else {
+			line += fmt.Sprintf("  (actual time=%s rows=%s loops=%s)", format(n.ActualTime), format(n.ActualRows), format(n.Loops))
+		}
+	}
+	if marks := r.marks(n); len(marks) != 0 {
+		r.println("! " + line + "  <-- " + strings.Join(marks, ", "))
+	} else {
+		r.println("  " + line)
+	}
+	for _, d := range n.Details {
+		r.println("  " + strings.Repeat(" ", col+2) + d)
+	}
+	for _, c := range n.Children {
+		r.render(c, col+2, true)
+	}
+}
+
+// marks returns the highlights for n.
+func (r *renderer) marks(n *Node) []string {
+	var marks []string
+	if cost := r.costs[n]; r.count > 1 && r.total > 0 && cost/r.total >= expensiveShare {
+		marks = append(marks, fmt.Sprintf("most expensive: %.0f%%", 100*cost/r.total))
+	}
+	if n.Analyzed && n.Loops != 0 {
+		est, actual := atLeastOne(n.Rows), atLeastOne(n.ActualRows)
+		switch {
+		case actual/est >= estimateMiss:
+			marks = append(marks, fmt.Sprintf("rows underestimated by %sx", format(actual/est)))
+		case est/actual >= estimateMiss:
+			marks = append(marks, fmt.Sprintf("rows overestimated by %sx", format(est/actual)))
+		}
+	}
+	if n.FullScan {
+		switch size := n.size(); {
+		case size >= largeTable:
+			marks = append(marks, fmt.Sprintf("full scan of large table: %s rows", format(size)))
+		case size == 0 && !n.Analyzed && n.Cost == 0:
+			// no estimates available
+			marks = append(marks, "full scan")
+		}
+	}
+	return marks
+}
+
+// size returns the number of rows read by a scan.
+func (n *Node) size() float64 {
+	size := n.Scanned
+	if n.Rows > size {
+		size = n.Rows
+	}
+	if n.Analyzed && n.ActualRows*loops(n) > size {
+		size = n.ActualRows * loops(n)
+	}
+	return size
+}
+
+// println writes a line, recording the first error.
+func (r *renderer) println(s string) {
+	if r.err == nil {
+		_, r.err = fmt.Fprintln(r.w, s)
+	}
+}
+
+// loops returns the number of loops of n, or 1 if not analyzed.
+func loops(n *Node) float64 {
+	if !n.Analyzed || n.Loops == 0 {
+		return 1
+	}
+	return n.Loops
+}
+
+// atLeastOne returns f, or 1 when f is less than 1.
+func atLeastOne(f float64) float64 {
+	if f < 1 {
+		return 1
+	}
+	return f
+}
+
+// format formats a float with at most 2 decimals.
+func format(f float64) string {
+	s := strconv.FormatFloat(f, 'f', 2, 64)
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// toFloat converts a JSON or XML value to a float.
+func toFloat(v interface{}) float64 {
+	switch x := v.(type) {
+	case float64:
+		return x
+	case string:
+		f, _ := strconv.ParseFloat(x, 64)
+		return f
+	}
+	return 0
+}
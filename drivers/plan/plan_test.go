@@ -0,0 +1,196 @@
+package plan
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParsePostgres(t *testing.T) {
+	buf := []byte(`[{"Plan": {
+		"Node Type": "Hash Join", "Join Type": "Left", "Total Cost": 300.5, "Plan Rows": 10,
+		"Actual Total Time": 25.0, "Actual Rows": 5000, "Actual Loops": 1,
+		"Hash Cond": "(o.user_id = u.id)",
+		"Plans": [
+			{"Node Type": "Seq Scan", "Relation Name": "orders", "Alias": "o", "Total Cost": 250, "Plan Rows": 50000,
+			 "Actual Total Time": 20.0, "Actual Rows": 50000, "Actual Loops": 1, "Filter": "(total > 0)", "Rows Removed by Filter": 10},
+			{"Node Type": "Hash", "Total Cost": 10, "Plan Rows": 100,
+			 "Actual Total Time": 1.0, "Actual Rows": 100, "Actual Loops": 1,
+			 "Plans": [
+				{"Node Type": "Index Scan", "Relation Name": "users", "Alias": "users", "Index Name": "users_pkey", "Total Cost": 10, "Plan Rows": 100,
+				 "Actual Total Time": 0.5, "Actual Rows": 100, "Actual Loops": 1}
+			 ]}
+		]}, "Planning Time": 0.1, "Execution Time": 25.5}]`)
+	n, err := ParsePostgres(buf)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if s, exp := n.Title(), "Hash Left Join"; s != exp {
+		t.Errorf("expected title %q, got: %q", exp, s)
+	}
+	if len(n.Children) != 2 || len(n.Children[1].Children) != 1 {
+		t.Fatalf("expected 2 children and 1 grandchild, got: %d", len(n.Children))
+	}
+	if s, exp := n.Children[0].Title(), "Seq Scan on orders o"; s != exp {
+		t.Errorf("expected title %q, got: %q", exp, s)
+	}
+	if s, exp := n.Children[1].Children[0].Title(), "Index Scan using users_pkey on users"; s != exp {
+		t.Errorf("expected title %q, got: %q", exp, s)
+	}
+	if !n.Children[0].FullScan || !n.Analyzed || n.Children[0].Scanned != 50010 {
+		t.Errorf("expected full analyzed scan of 50010 rows, got: %+v", n.Children[0])
+	}
+	var b bytes.Buffer
+	if err := Render(&b, n); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp := `! Hash Left Join  (cost=300.5 rows=10)  (actual time=25 rows=5000 loops=1)  <-- rows underestimated by 500x
+    Hash Cond: (o.user_id = u.id)
+!   ->  Seq Scan on orders o  (cost=250 rows=50000)  (actual time=20 rows=50000 loops=1)  <-- most expensive: 80%, full scan of large table: 50010 rows
+          Filter: (total > 0)
+    ->  Hash  (cost=10 rows=100)  (actual time=1 rows=100 loops=1)
+          ->  Index Scan using users_pkey on users  (cost=10 rows=100)  (actual time=0.5 rows=100 loops=1)
+`
+	if s := b.String(); s != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, s)
+	}
+}
+
+func TestParseMySQL(t *testing.T) {
+	buf := []byte(`{"query_block": {"select_id": 1, "cost_info": {"query_cost": "1210.50"},
+		"nested_loop": [
+			{"table": {"table_name": "o", "access_type": "ALL", "rows_examined_per_scan": 100000, "rows_produced_per_join": 10000,
+			 "filtered": "10.00", "cost_info": {"read_cost": "900.00", "eval_cost": "100.00", "prefix_cost": "1000.00"},
+			 "attached_condition": "(o.total > 0)"}},
+			{"table": {"table_name": "u", "access_type": "eq_ref", "key": "PRIMARY", "rows_examined_per_scan": 1, "rows_produced_per_join": 10000,
+			 "cost_info": {"read_cost": "110.00", "eval_cost": "100.00", "prefix_cost": "1210.00"}}}
+		]}}`)
+	n, err := ParseMySQL(buf)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if n.Op != "Query Block" || n.Cost != 1210.5 || len(n.Children) != 1 {
+		t.Fatalf("expected query block with 1 child, got: %+v", n)
+	}
+	loop := n.Children[0]
+	if loop.Op != "Nested Loop" || loop.Cost != 1210 || len(loop.Children) != 2 {
+		t.Fatalf("expected nested loop with 2 children, got: %+v", loop)
+	}
+	if s, exp := loop.Children[0].Title(), "Table Scan on o"; s != exp || !loop.Children[0].FullScan || loop.Children[0].Scanned != 100000 {
+		t.Errorf("expected full scan %q, got: %q %+v", exp, s, loop.Children[0])
+	}
+	if s, exp := loop.Children[1].Title(), "Unique Index Lookup using PRIMARY on u"; s != exp {
+		t.Errorf("expected title %q, got: %q", exp, s)
+	}
+}
+
+func TestParseMySQLTree(t *testing.T) {
+	s := `-> Nested loop inner join  (cost=1210.50 rows=10000) (actual time=0.1..35.2 rows=9000 loops=1)
+    -> Filter: (o.total > 0)  (cost=1000.00 rows=10000) (actual time=0.05..30.1 rows=9000 loops=1)
+        -> Table scan on o  (cost=1000.00 rows=100000) (actual time=0.04..25.3 rows=100000 loops=1)
+    -> Single-row index lookup on u using PRIMARY (id=o.user_id)  (cost=0.25 rows=1) (never executed)
+`
+	n, err := ParseMySQLTree(s)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if n.Op != "Nested loop inner join" || n.ActualTime != 35.2 || n.ActualRows != 9000 || len(n.Children) != 2 {
+		t.Fatalf("expected nested loop with 2 children, got: %+v", n)
+	}
+	filter := n.Children[0]
+	if filter.Op != "Filter" || len(filter.Details) != 1 || filter.Details[0] != "Filter: (o.total > 0)" || len(filter.Children) != 1 {
+		t.Errorf("expected filter with 1 child, got: %+v", filter)
+	}
+	if scan := filter.Children[0]; scan.Title() != "Table scan on o" || !scan.FullScan || scan.Rows != 100000 {
+		t.Errorf("expected table scan, got: %+v", scan)
+	}
+	lookup := n.Children[1]
+	if s, exp := lookup.Title(), "Single-row index lookup using PRIMARY on u"; s != exp || !lookup.Analyzed || lookup.Loops != 0 {
+		t.Errorf("expected unexecuted %q, got: %q %+v", exp, s, lookup)
+	}
+}
+
+func TestParseSQLite(t *testing.T) {
+	n := ParseSQLite([]SQLiteRow{
+		{2, 0, "SCAN t1"},
+		{5, 0, "LIST SUBQUERY 1"},
+		{7, 5, "SCAN t"},
+		{12, 0, "SEARCH t2 USING INDEX t_b (b=?)"},
+		{15, 0, "SEARCH t3 USING INTEGER PRIMARY KEY (rowid=?)"},
+		{18, 0, "SCAN t4 USING COVERING INDEX t4_a"},
+	})
+	var b bytes.Buffer
+	if err := Render(&b, n); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp := `  Query Plan
+!   ->  Scan on t1  <-- full scan
+    ->  LIST SUBQUERY 1
+!         ->  Scan on t  <-- full scan
+    ->  Search using t_b on t2
+          Cond: b=?
+    ->  Search using primary key on t3
+          Cond: rowid=?
+    ->  Scan using t4_a on t4
+`
+	if s := b.String(); s != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, s)
+	}
+}
+
+func TestParseSQLServer(t *testing.T) {
+	s := `<?xml version="1.0" encoding="utf-16"?>
+<ShowPlanXML xmlns="http://schemas.microsoft.com/sqlserver/2004/07/showplan" Version="1.564">
+  <BatchSequence><Batch><Statements>
+    <StmtSimple StatementText="select * from t where a = 1" StatementSubTreeCost="0.5">
+      <QueryPlan>
+        <RelOp NodeId="0" PhysicalOp="Nested Loops" LogicalOp="Inner Join" EstimateRows="10" EstimatedTotalSubtreeCost="0.5">
+          <RunTimeInformation><RunTimeCountersPerThread Thread="0" ActualRows="500" ActualExecutions="1" ActualElapsedms="12"/></RunTimeInformation>
+          <NestedLoops>
+            <RelOp NodeId="1" PhysicalOp="Table Scan" LogicalOp="Table Scan" EstimateRows="10" EstimatedTotalSubtreeCost="0.4" TableCardinality="20000">
+              <RunTimeInformation><RunTimeCountersPerThread Thread="0" ActualRows="500" ActualExecutions="1" ActualElapsedms="10"/></RunTimeInformation>
+              <TableScan>
+                <Predicate><ScalarOperator ScalarString="[db].[dbo].[t].[a]=(1)"/></Predicate>
+                <Object Database="[db]" Schema="[dbo]" Table="[t]"/>
+              </TableScan>
+            </RelOp>
+            <RelOp NodeId="2" PhysicalOp="Index Seek" LogicalOp="Index Seek" EstimateRows="1" EstimatedTotalSubtreeCost="0.05">
+              <RunTimeInformation><RunTimeCountersPerThread Thread="0" ActualRows="1000" ActualExecutions="500" ActualElapsedms="1"/></RunTimeInformation>
+              <IndexScan>
+                <Object Database="[db]" Schema="[dbo]" Table="[u]" Index="[u_pk]" Alias="[x]"/>
+              </IndexScan>
+            </RelOp>
+          </NestedLoops>
+        </RelOp>
+      </QueryPlan>
+    </StmtSimple>
+  </Statements></Batch></BatchSequence>
+</ShowPlanXML>`
+	n, err := ParseSQLServer(s)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var b bytes.Buffer
+	if err := Render(&b, n); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	exp := `! Nested Loops (Inner Join)  (cost=0.5 rows=10)  (actual time=12 rows=500 loops=1)  <-- rows underestimated by 50x
+!   ->  Table Scan on dbo.t  (cost=0.4 rows=10)  (actual time=10 rows=500 loops=1)  <-- most expensive: 83%, rows underestimated by 50x, full scan of large table: 20000 rows
+          Predicate: [db].[dbo].[t].[a]=(1)
+    ->  Index Seek using u_pk on dbo.u x  (cost=0.05 rows=1)  (actual time=0 rows=2 loops=500)
+`
+	if s := b.String(); s != exp {
+		t.Errorf("expected:\n%s\ngot:\n%s", exp, s)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	if _, err := ParsePostgres([]byte(`[]`)); err == nil {
+		t.Errorf("expected error for empty postgres plan")
+	}
+	if _, err := ParseMySQLTree(""); err == nil {
+		t.Errorf("expected error for empty mysql plan")
+	}
+	if _, err := ParseSQLServer(`<ShowPlanXML/>`); err == nil {
+		t.Errorf("expected error for empty sqlserver plan")
+	}
+}
@@ -0,0 +1,105 @@
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Postgres explains a query using PostgreSQL's EXPLAIN (FORMAT JSON).
+func Postgres(ctx context.Context, db DB, sqlstr string, analyze bool) (*Node, error) {
+	opts := "FORMAT JSON"
+	if analyze {
+		opts = "ANALYZE, " + opts
+	}
+	var buf []byte
+	if err := db.QueryRowContext(ctx, `EXPLAIN (`+opts+`) `+sqlstr).Scan(&buf); err != nil {
+		return nil, err
+	}
+	return ParsePostgres(buf)
+}
+
+// pgPlan is a PostgreSQL JSON plan node.
+type pgPlan struct {
+	NodeType            string   `json:"Node Type"`
+	RelationName        string   `json:"Relation Name"`
+	Alias               string   `json:"Alias"`
+	IndexName           string   `json:"Index Name"`
+	JoinType            string   `json:"Join Type"`
+	TotalCost           float64  `json:"Total Cost"`
+	PlanRows            float64  `json:"Plan Rows"`
+	ActualTotalTime     *float64 `json:"Actual Total Time"`
+	ActualRows          float64  `json:"Actual Rows"`
+	ActualLoops         float64  `json:"Actual Loops"`
+	RowsRemovedByFilter float64  `json:"Rows Removed by Filter"`
+	IndexCond           string   `json:"Index Cond"`
+	HashCond            string   `json:"Hash Cond"`
+	MergeCond           string   `json:"Merge Cond"`
+	JoinFilter          string   `json:"Join Filter"`
+	RecheckCond         string   `json:"Recheck Cond"`
+	Filter              string   `json:"Filter"`
+	SortKey             []string `json:"Sort Key"`
+	GroupKey            []string `json:"Group Key"`
+	Plans               []pgPlan `json:"Plans"`
+}
+
+// ParsePostgres parses a PostgreSQL JSON plan.
+func ParsePostgres(buf []byte) (*Node, error) {
+	var res []struct {
+		Plan pgPlan `json:"Plan"`
+	}
+	if err := json.Unmarshal(buf, &res); err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("empty plan")
+	}
+	return res[0].Plan.node(), nil
+}
+
+// node converts the plan to a node.
+func (p pgPlan) node() *Node {
+	n := &Node{
+		Op:       p.NodeType,
+		Relation: p.RelationName,
+		Index:    p.IndexName,
+		Cost:     p.TotalCost,
+		Rows:     p.PlanRows,
+		FullScan: p.NodeType == "Seq Scan",
+	}
+	if p.JoinType != "" && p.JoinType != "Inner" {
+		if strings.HasSuffix(n.Op, " Join") {
+			n.Op = strings.TrimSuffix(n.Op, " Join") + " " + p.JoinType + " Join"
+		} else {
+			n.Op += " " + p.JoinType + " Join"
+		}
+	}
+	if p.Alias != "" && p.Alias != p.RelationName {
+		n.Relation += " " + p.Alias
+	}
+	if p.ActualTotalTime != nil {
+		n.Analyzed, n.ActualTime, n.ActualRows, n.Loops = true, *p.ActualTotalTime, p.ActualRows, p.ActualLoops
+		n.Scanned = (p.ActualRows + p.RowsRemovedByFilter) * p.ActualLoops
+	}
+	for _, d := range []struct {
+		name, value string
+	}{
+		{"Index Cond", p.IndexCond},
+		{"Hash Cond", p.HashCond},
+		{"Merge Cond", p.MergeCond},
+		{"Join Filter", p.JoinFilter},
+		{"Recheck Cond", p.RecheckCond},
+		{"Filter", p.Filter},
+		{"Sort Key", strings.Join(p.SortKey, ", ")},
+		{"Group Key", strings.Join(p.GroupKey, ", ")},
+	} {
+		if d.value != "" {
+			n.Details = append(n.Details, d.name+": "+d.value)
+		}
+	}
+	for _, c := range p.Plans {
+		n.Children = append(n.Children, c.node())
+	}
+	return n
+}
@@ -0,0 +1,81 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// SQLite explains a query using SQLite's EXPLAIN QUERY PLAN. SQLite does not
+// support analyzing a query.
+func SQLite(ctx context.Context, db DB, sqlstr string, analyze bool) (*Node, error) {
+	if analyze {
+		return nil, errors.New("EXPLAIN ANALYZE is not supported by SQLite")
+	}
+	rows, err := db.QueryContext(ctx, `EXPLAIN QUERY PLAN `+sqlstr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var plan []SQLiteRow
+	for rows.Next() {
+		var r SQLiteRow
+		var notused interface{}
+		if err := rows.Scan(&r.ID, &r.Parent, &notused, &r.Detail); err != nil {
+			return nil, err
+		}
+		plan = append(plan, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ParseSQLite(plan), nil
+}
+
+// SQLiteRow is a row of a SQLite query plan.
+type SQLiteRow struct {
+	ID     int64
+	Parent int64
+	Detail string
+}
+
+// sqliteRE matches the detail of a SQLite query plan row.
+var sqliteRE = regexp.MustCompile(`^(SCAN|SEARCH)(?: TABLE)? (\S+)(?: AS \S+)?(?: USING (?:(?:COVERING |AUTOMATIC (?:COVERING |PARTIAL COVERING )?)?INDEX (\S+)|(INTEGER PRIMARY KEY))?)?(?: \((.*)\))?`)
+
+// ParseSQLite parses the rows of a SQLite query plan.
+func ParseSQLite(plan []SQLiteRow) *Node {
+	root := &Node{
+		Op: "Query Plan",
+	}
+	nodes := map[int64]*Node{0: root}
+	for _, r := range plan {
+		n := &Node{
+			Op: r.Detail,
+		}
+		if m := sqliteRE.FindStringSubmatch(r.Detail); m != nil {
+			n.Op, n.Relation, n.Index = "Scan", m[2], m[3]
+			switch {
+			case m[1] == "SEARCH":
+				n.Op = "Search"
+			case m[3] == "" && m[4] == "":
+				n.FullScan = true
+			}
+			if m[4] != "" {
+				n.Index = "primary key"
+			}
+			if m[5] != "" {
+				n.Details = append(n.Details, "Cond: "+m[5])
+			}
+		}
+		p, ok := nodes[r.Parent]
+		if !ok {
+			p = root
+		}
+		p.Children = append(p.Children, n)
+		nodes[r.ID] = n
+	}
+	if len(root.Children) == 1 {
+		return root.Children[0]
+	}
+	return root
+}
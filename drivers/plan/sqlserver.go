@@ -0,0 +1,183 @@
+package plan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SQLServer explains a query using SQL Server's SET SHOWPLAN_XML, or SET
+// STATISTICS XML when analyzing.
+func SQLServer(ctx context.Context, db DB, sqlstr string, analyze bool) (*Node, error) {
+	// settings apply to the session, so use a single connection
+	if d, ok := db.(*sql.DB); ok {
+		conn, err := d.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		db = conn
+	}
+	set := "SHOWPLAN_XML"
+	if analyze {
+		set = "STATISTICS XML"
+	}
+	if _, err := db.ExecContext(ctx, `SET `+set+` ON`); err != nil {
+		return nil, err
+	}
+	defer db.ExecContext(context.Background(), `SET `+set+` OFF`)
+	rows, err := db.QueryContext(ctx, sqlstr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	// the plan is the last result set with a single XML showplan column
+	var buf string
+	for {
+		cols, err := rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			if len(cols) != 1 || !strings.Contains(cols[0], "Showplan") {
+				continue
+			}
+			if err := rows.Scan(&buf); err != nil {
+				return nil, err
+			}
+		}
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ParseSQLServer(buf)
+}
+
+// ParseSQLServer parses a SQL Server XML showplan.
+func ParseSQLServer(s string) (*Node, error) {
+	root := &Node{
+		Op: "Batch",
+	}
+	var stack []*Node
+	dec := xml.NewDecoder(strings.NewReader(s))
+	// the plan is declared as utf-16, but has already been decoded
+	dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) {
+		return r, nil
+	}
+	for {
+		tok, err := dec.Token()
+		switch {
+		case errors.Is(err, io.EOF):
+			switch {
+			case len(root.Children) == 0:
+				return nil, fmt.Errorf("empty plan")
+			case len(root.Children) == 1:
+				return root.Children[0], nil
+			}
+			return root, nil
+		case err != nil:
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var parent *Node
+			if len(stack) != 0 {
+				parent = stack[len(stack)-1]
+			}
+			switch t.Name.Local {
+			case "RelOp":
+				n := sqlserverRelOp(t.Attr)
+				if parent != nil {
+					parent.Children = append(parent.Children, n)
+				} else {
+					root.Children = append(root.Children, n)
+				}
+				stack = append(stack, n)
+			case "Object":
+				if parent != nil && parent.Relation == "" {
+					parent.Relation = sqlserverName(attr(t.Attr, "Schema"), attr(t.Attr, "Table"), attr(t.Attr, "Alias"))
+					parent.Index = strings.Trim(attr(t.Attr, "Index"), "[]")
+				}
+			case "RunTimeCountersPerThread":
+				if parent != nil {
+					parent.Analyzed = true
+					parent.ActualRows += toFloat(attr(t.Attr, "ActualRows"))
+					parent.Loops += toFloat(attr(t.Attr, "ActualExecutions"))
+					if ms := toFloat(attr(t.Attr, "ActualElapsedms")); ms > parent.ActualTime {
+						parent.ActualTime = ms
+					}
+				}
+			case "Predicate":
+				if parent != nil {
+					parent.Details = append(parent.Details, "Predicate: ...")
+				}
+			case "SeekPredicates":
+				if parent != nil {
+					parent.Details = append(parent.Details, "Seek Predicate: ...")
+				}
+			case "ScalarOperator":
+				// replace the placeholder of the predicate with its expression
+				if parent != nil && len(parent.Details) != 0 && strings.HasSuffix(parent.Details[len(parent.Details)-1], ": ...") {
+					d := &parent.Details[len(parent.Details)-1]
+					*d = strings.TrimSuffix(*d, "...") + attr(t.Attr, "ScalarString")
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "RelOp" {
+				// actual values are totals of all executions
+				if n := stack[len(stack)-1]; n.Loops != 0 {
+					n.ActualRows, n.ActualTime = n.ActualRows/n.Loops, n.ActualTime/n.Loops
+				}
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+// sqlserverRelOp creates a node for a SQL Server plan operation.
+func sqlserverRelOp(attrs []xml.Attr) *Node {
+	op := attr(attrs, "PhysicalOp")
+	if logical := attr(attrs, "LogicalOp"); logical != "" && logical != op {
+		op += " (" + logical + ")"
+	}
+	n := &Node{
+		Op:       op,
+		Cost:     toFloat(attr(attrs, "EstimatedTotalSubtreeCost")),
+		Rows:     toFloat(attr(attrs, "EstimateRows")),
+		Scanned:  toFloat(attr(attrs, "TableCardinality")),
+		FullScan: strings.HasPrefix(op, "Table Scan") || strings.HasPrefix(op, "Clustered Index Scan"),
+	}
+	if n.Scanned == 0 {
+		n.Scanned = toFloat(attr(attrs, "EstimatedRowsRead"))
+	}
+	return n
+}
+
+// sqlserverName returns the display name of a table.
+func sqlserverName(schema, table, alias string) string {
+	name := strings.Trim(table, "[]")
+	if s := strings.Trim(schema, "[]"); s != "" {
+		name = s + "." + name
+	}
+	if a := strings.Trim(alias, "[]"); a != "" && a != strings.Trim(table, "[]") {
+		name += " " + a
+	}
+	return name
+}
+
+// attr returns the value of the named attribute.
+func attr(attrs []xml.Attr, name string) string {
+	for _, a := range attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
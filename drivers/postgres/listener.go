@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"github.com/lib/pq"
+	"github.com/xo/dburl"
+	"github.com/xo/usql/drivers"
+)
+
+// listener is a dedicated connection listening for notifications.
+type listener struct {
+	conn *pq.ListenerConn
+	// ready is signaled when a notification is queued or the connection is
+	// closed
+	ready  chan struct{}
+	mu     sync.Mutex
+	queue  []*pq.Notification
+	closed bool
+}
+
+// newListener creates a new listener.
+func newListener(_ context.Context, u *dburl.URL, _ *sql.DB) (drivers.Listener, error) {
+	notify := make(chan *pq.Notification, 32)
+	conn, err := pq.NewListenerConn(u.DSN, notify)
+	if err != nil {
+		return nil, err
+	}
+	l := &listener{
+		conn:  conn,
+		ready: make(chan struct{}, 1),
+	}
+	go l.drain(notify)
+	return l, nil
+}
+
+// drain queues notifications until the connection is closed, since the
+// connection blocks when notifications are not received.
+func (l *listener) drain(notify <-chan *pq.Notification) {
+	for n := range notify {
+		l.mu.Lock()
+		l.queue = append(l.queue, n)
+		l.mu.Unlock()
+		l.signal()
+	}
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+	l.signal()
+}
+
+// signal wakes up a waiting Wait.
+func (l *listener) signal() {
+	select {
+	case l.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Listen satisfies the drivers.Listener interface.
+func (l *listener) Listen(_ context.Context, channel string) error {
+	_, err := l.conn.Listen(channel)
+	return err
+}
+
+// Unlisten satisfies the drivers.Listener interface.
+func (l *listener) Unlisten(_ context.Context, channel string) error {
+	var err error
+	if channel == "" {
+		_, err = l.conn.UnlistenAll()
+	} else {
+		_, err = l.conn.Unlisten(channel)
+	}
+	return err
+}
+
+// Wait satisfies the drivers.Listener interface.
+func (l *listener) Wait(ctx context.Context) (*drivers.Notification, error) {
+	for {
+		l.mu.Lock()
+		if len(l.queue) != 0 {
+			n := l.queue[0]
+			l.queue[0], l.queue = nil, l.queue[1:]
+			l.mu.Unlock()
+			return &drivers.Notification{
+				Channel: n.Channel,
+				Payload: n.Extra,
+				PID:     n.BePid,
+			}, nil
+		}
+		closed := l.closed
+		l.mu.Unlock()
+		if closed {
+			if err := l.conn.Err(); err != nil {
+				return nil, err
+			}
+			return nil, errors.New("listener connection closed")
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-l.ready:
+		}
+	}
+}
+
+// Close satisfies the drivers.Listener interface.
+func (l *listener) Close() error {
+	return l.conn.Close()
+}
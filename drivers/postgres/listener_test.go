@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestListenerWait(t *testing.T) {
+	notify := make(chan *pq.Notification)
+	l := &listener{ready: make(chan struct{}, 1)}
+	go l.drain(notify)
+	// more notifications than buffered by the connection, without waiting
+	for i := 0; i < 100; i++ {
+		select {
+		case notify <- &pq.Notification{Channel: "c", Extra: strconv.Itoa(i)}:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected notification %d to be queued", i)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for i := 0; i < 100; i++ {
+		n, err := l.Wait(ctx)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		if exp := strconv.Itoa(i); n.Payload != exp {
+			t.Errorf("test %d expected payload %q, got: %q", i, exp, n.Payload)
+		}
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got: %v", context.DeadlineExceeded, err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/xo/usql/drivers"
 	"github.com/xo/usql/drivers/metadata"
 	pgmeta "github.com/xo/usql/drivers/metadata/postgres"
+	"github.com/xo/usql/drivers/plan"
 	"github.com/xo/usql/env"
 	"github.com/xo/usql/text"
 )
@@ -50,6 +51,7 @@ func init() {
 		Name:                   "pq",
 		AllowDollar:            true,
 		AllowMultilineComments: true,
+		AllowEStrings:          true,
 		LexerName:              "postgres",
 		ForceParams: func(u *dburl.URL) {
 			if u.Scheme == "cockroachdb" {
@@ -106,9 +108,12 @@ func init() {
 			return false
 		},
 		NewMetadataReader: pgmeta.NewReader(),
+		Dialect:           pgmeta.Dialect,
 		NewMetadataWriter: func(db drivers.DB, w io.Writer, opts ...metadata.ReaderOption) metadata.Writer {
 			return metadata.NewDefaultWriter(pgmeta.NewReader()(db, opts...))(db, w)
 		},
+		NewListener: newListener,
+		Explain:     plan.Postgres,
 		Copy: func(ctx context.Context, db *sql.DB, rows *sql.Rows, table string) (int64, error) {
 			columns, err := rows.Columns()
 			if err != nil {
@@ -14,9 +14,12 @@ func init() {
 	drivers.Register("ql", drivers.Driver{
 		AllowMultilineComments: true,
 		AllowCComments:         true,
+		AllowBackslashEscapes:  true,
+		AllowBackticks:         true,
 		BatchQueryPrefixes: map[string]string{
 			"BEGIN TRANSACTION": "COMMIT",
 		},
 		BatchAsTransaction: true,
+		NewMetadataReader:  NewMetadataReader,
 	})
 }
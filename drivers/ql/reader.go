@@ -0,0 +1,206 @@
+package ql
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/metadata"
+)
+
+type MetadataReader struct {
+	metadata.LoggingReader
+}
+
+// NewMetadataReader creates the metadata reader for ql databases.
+func NewMetadataReader(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
+	return &MetadataReader{
+		LoggingReader: metadata.NewLoggingReader(db, opts...),
+	}
+}
+
+var (
+	_ metadata.BasicReader       = &MetadataReader{}
+	_ metadata.IndexReader       = &MetadataReader{}
+	_ metadata.IndexColumnReader = &MetadataReader{}
+)
+
+// Schemas returns no schemas, since ql databases do not have any.
+func (r MetadataReader) Schemas(metadata.Filter) (*metadata.SchemaSet, error) {
+	return metadata.NewSchemaSet([]metadata.Schema{}), nil
+}
+
+func (r MetadataReader) Tables(f metadata.Filter) (*metadata.TableSet, error) {
+	qstr := `SELECT
+  Name
+FROM __Table`
+	var conds []string
+	var vals []interface{}
+	if !f.WithSystem {
+		conds = append(conds, `!hasPrefix(Name, "__")`)
+	}
+	if f.Name != "" {
+		vals = append(vals, metadata.LikeRegexp(f.Name))
+		conds = append(conds, "Name LIKE $"+strconv.Itoa(len(vals)))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "Name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Table
+	for rows.Next() {
+		rec := metadata.Table{Type: "TABLE"}
+		if err := rows.Scan(&rec.Name); err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(rec.Name, "__") {
+			rec.Type = "SYSTEM TABLE"
+		}
+		if !metadata.HasType(f.Types, rec.Type) {
+			continue
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewTableSet(results), nil
+}
+
+func (r MetadataReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
+	qstr := `SELECT
+  c.TableName,
+  c.Ordinal,
+  c.Name,
+  c.Type,
+  c2.NotNull,
+  c2.DefaultExpr
+FROM __Column AS c
+LEFT OUTER JOIN __Column2 AS c2 ON c.TableName == c2.TableName && c.Name == c2.Name`
+	var conds []string
+	var vals []interface{}
+	if f.Parent != "" {
+		vals = append(vals, metadata.LikeRegexp(f.Parent))
+		conds = append(conds, "c.TableName LIKE $"+strconv.Itoa(len(vals)))
+	}
+	if f.Name != "" {
+		vals = append(vals, metadata.LikeRegexp(f.Name))
+		conds = append(conds, "c.Name LIKE $"+strconv.Itoa(len(vals)))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "c.TableName, c.Ordinal", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Column
+	for rows.Next() {
+		var rec metadata.Column
+		var notNull sql.NullBool
+		var def sql.NullString
+		if err := rows.Scan(&rec.Table, &rec.OrdinalPosition, &rec.Name, &rec.DataType, &notNull, &def); err != nil {
+			return nil, err
+		}
+		rec.IsNullable, rec.Default = metadata.YES, def.String
+		if notNull.Bool {
+			rec.IsNullable = metadata.NO
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewColumnSet(results), nil
+}
+
+func (r MetadataReader) Indexes(f metadata.Filter) (*metadata.IndexSet, error) {
+	qstr := `SELECT
+  TableName,
+  IndexName,
+  IsUnique
+FROM __Index2`
+	conds, vals := indexConds("", f)
+	rows, closeRows, err := r.query(qstr, conds, "TableName, IndexName", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Index
+	for rows.Next() {
+		rec := metadata.Index{IsPrimary: metadata.NO, IsUnique: metadata.NO}
+		var unique bool
+		if err := rows.Scan(&rec.Table, &rec.Name, &unique); err != nil {
+			return nil, err
+		}
+		if unique {
+			rec.IsUnique = metadata.YES
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewIndexSet(results), nil
+}
+
+func (r MetadataReader) IndexColumns(f metadata.Filter) (*metadata.IndexColumnSet, error) {
+	qstr := `SELECT
+  i.TableName,
+  i.IndexName,
+  e.Expr
+FROM __Index2 AS i, __Index2_Expr AS e`
+	conds, vals := indexConds("i.", f)
+	conds = append([]string{"id(i) == e.Index2_ID"}, conds...)
+	// expressions are in the order of the index definition
+	rows, closeRows, err := r.query(qstr, conds, "i.TableName, i.IndexName, id(e)", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.IndexColumn
+	for rows.Next() {
+		var rec metadata.IndexColumn
+		if err := rows.Scan(&rec.Table, &rec.IndexName, &rec.Name); err != nil {
+			return nil, err
+		}
+		if n := len(results); n != 0 && results[n-1].Table == rec.Table && results[n-1].IndexName == rec.IndexName {
+			rec.OrdinalPosition = results[n-1].OrdinalPosition
+		}
+		rec.OrdinalPosition++
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewIndexColumnSet(results), nil
+}
+
+// indexConds returns conditions of the filter for __Index2 columns with the
+// prefix.
+func indexConds(prefix string, f metadata.Filter) ([]string, []interface{}) {
+	var conds []string
+	var vals []interface{}
+	if !f.WithSystem {
+		conds = append(conds, `!hasPrefix(`+prefix+`TableName, "__")`)
+	}
+	if f.Parent != "" {
+		vals = append(vals, metadata.LikeRegexp(f.Parent))
+		conds = append(conds, prefix+"TableName LIKE $"+strconv.Itoa(len(vals)))
+	}
+	if f.Name != "" {
+		vals = append(vals, metadata.LikeRegexp(f.Name))
+		conds = append(conds, prefix+"IndexName LIKE $"+strconv.Itoa(len(vals)))
+	}
+	return conds, vals
+}
+
+func (r MetadataReader) query(qstr string, conds []string, order string, vals ...interface{}) (*sql.Rows, func(), error) {
+	if len(conds) != 0 {
+		qstr += "\nWHERE " + strings.Join(conds, " && ")
+	}
+	if order != "" {
+		qstr += "\nORDER BY " + order
+	}
+	return r.Query(qstr, vals...)
+}
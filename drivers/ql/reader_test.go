@@ -0,0 +1,118 @@
+package ql
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/xo/usql/drivers/metadata"
+)
+
+func newReader(t *testing.T) *MetadataReader {
+	t.Helper()
+	db, err := sql.Open("ql", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("could not open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("could not begin transaction: %v", err)
+	}
+	for _, q := range []string{
+		`CREATE TABLE film (title string NOT NULL, length int64 DEFAULT 90, rating float64)`,
+		`CREATE TABLE actor (name string)`,
+		`CREATE UNIQUE INDEX film_title ON film (title)`,
+		`CREATE INDEX film_title_length ON film (title, length)`,
+		`CREATE INDEX actor_id ON actor (id())`,
+	} {
+		if _, err := tx.Exec(q); err != nil {
+			t.Fatalf("could not execute %q: %v", q, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("could not commit: %v", err)
+	}
+	return NewMetadataReader(db).(*MetadataReader)
+}
+
+func TestTables(t *testing.T) {
+	r := newReader(t)
+	tests := []struct {
+		filter metadata.Filter
+		exp    string
+	}{
+		{metadata.Filter{}, "actor:TABLE, film:TABLE"},
+		{metadata.Filter{Name: "f%"}, "film:TABLE"},
+		{metadata.Filter{Name: "f.*"}, ""},
+		{metadata.Filter{Name: "__Index_", WithSystem: true}, "__Index2:SYSTEM TABLE"},
+		{metadata.Filter{Types: []string{"VIEW"}}, ""},
+		{metadata.Filter{Types: []string{"TABLE"}, WithSystem: true}, "actor:TABLE, film:TABLE"},
+	}
+	for i, test := range tests {
+		res, err := r.Tables(test.filter)
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		var names []string
+		for res.Next() {
+			names = append(names, res.Get().Name+":"+res.Get().Type)
+		}
+		if s := strings.Join(names, ", "); s != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, s)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	r := newReader(t)
+	res, err := r.Columns(metadata.Filter{Parent: "film"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var cols []string
+	for res.Next() {
+		c := res.Get()
+		cols = append(cols, strings.Join([]string{c.Name, c.DataType, string(c.IsNullable), c.Default}, " "))
+	}
+	exp := "title string NO , length int64 YES 90, rating float64 YES "
+	if s := strings.Join(cols, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	r := newReader(t)
+	res, err := r.Indexes(metadata.Filter{})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		i := res.Get()
+		names = append(names, i.Table+"."+i.Name+":"+string(i.IsUnique))
+	}
+	exp := "actor.actor_id:NO, film.film_title:YES, film.film_title_length:NO"
+	if s := strings.Join(names, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestIndexColumns(t *testing.T) {
+	r := newReader(t)
+	res, err := r.IndexColumns(metadata.Filter{Parent: "film"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		c := res.Get()
+		names = append(names, c.IndexName+"."+c.Name+":"+strconv.Itoa(c.OrdinalPosition))
+	}
+	exp := "film_title.title:1, film_title_length.title:1, film_title_length.length:2"
+	if s := strings.Join(names, ", "); s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
@@ -281,3 +281,27 @@ func QueryExecType(prefix, sqlstr string) (string, bool) {
 	}
 	return s[0], false
 }
+
+// ddlMap is the map of SQL prefixes that change database metadata.
+var ddlMap = map[string]bool{
+	"ALTER":       true,
+	"COMMENT":     true,
+	"CREATE":      true,
+	"DROP":        true,
+	"GRANT":       true,
+	"IMPORT":      true, // import foreign schema (postgresql)
+	"RENAME":      true,
+	"REVOKE":      true,
+	"SELECT INTO": true,
+}
+
+// IsDDLPrefix returns whether the prefix (as returned by stmt.FindPrefix)
+// is for a statement changing database metadata.
+func IsDDLPrefix(prefix string) bool {
+	typ, _ := QueryExecType(prefix, "")
+	if ddlMap[typ] {
+		return true
+	}
+	s := strings.SplitN(typ, " ", 2)
+	return ddlMap[s[0]]
+}
@@ -18,6 +18,7 @@ func init() {
 	endRE := regexp.MustCompile(`;?\s*$`)
 	drivers.Register("tds", drivers.Driver{
 		AllowMultilineComments:  true,
+		AllowBrackets:           true,
 		RequirePreviousPassword: true,
 		LexerName:               "tsql",
 		Version: func(ctx context.Context, db drivers.DB) (string, error) {
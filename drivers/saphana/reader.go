@@ -0,0 +1,492 @@
+package saphana
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/metadata"
+)
+
+type MetadataReader struct {
+	metadata.LoggingReader
+}
+
+// NewMetadataReader creates the metadata reader for SAP HANA databases.
+func NewMetadataReader(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
+	return &MetadataReader{
+		LoggingReader: metadata.NewLoggingReader(db, opts...),
+	}
+}
+
+var (
+	_ metadata.BasicReader            = &MetadataReader{}
+	_ metadata.IndexReader            = &MetadataReader{}
+	_ metadata.IndexColumnReader      = &MetadataReader{}
+	_ metadata.ConstraintReader       = &MetadataReader{}
+	_ metadata.ConstraintColumnReader = &MetadataReader{}
+	_ metadata.SequenceReader         = &MetadataReader{}
+)
+
+func (r MetadataReader) Schemas(f metadata.Filter) (*metadata.SchemaSet, error) {
+	qstr := `SELECT
+  schema_name
+FROM SYS.SCHEMAS`
+	conds, vals := schemaConds("schema_name", f)
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "schema_name LIKE ?")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "schema_name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Schema
+	for rows.Next() {
+		var rec metadata.Schema
+		if err := rows.Scan(&rec.Schema); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewSchemaSet(results), nil
+}
+
+func (r MetadataReader) Tables(f metadata.Filter) (*metadata.TableSet, error) {
+	qstr := `SELECT
+  schema_name,
+  table_name,
+  table_type,
+  table_comment
+FROM (
+  SELECT
+    schema_name,
+    table_name,
+    CASE
+      WHEN is_system_table = 'TRUE' THEN 'SYSTEM TABLE'
+      WHEN is_temporary = 'TRUE' THEN 'GLOBAL TEMPORARY'
+      ELSE 'TABLE'
+    END AS table_type,
+    COALESCE(comments, '') AS table_comment
+  FROM SYS.TABLES
+  UNION ALL
+  SELECT
+    schema_name,
+    view_name,
+    'VIEW',
+    COALESCE(comments, '')
+  FROM SYS.VIEWS
+) t`
+	conds, vals := schemaConds("schema_name", f)
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "table_name LIKE ?")
+	}
+	if len(f.Types) != 0 {
+		var pholders []string
+		for _, t := range f.Types {
+			vals = append(vals, t)
+			pholders = append(pholders, "?")
+		}
+		conds = append(conds, "table_type IN ("+strings.Join(pholders, ", ")+")")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "schema_name, table_name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Table
+	for rows.Next() {
+		var rec metadata.Table
+		if err := rows.Scan(&rec.Schema, &rec.Name, &rec.Type, &rec.Comment); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewTableSet(results), nil
+}
+
+func (r MetadataReader) Columns(f metadata.Filter) (*metadata.ColumnSet, error) {
+	qstr := `SELECT
+  schema_name,
+  table_name,
+  column_name,
+  position,
+  data_type_name,
+  COALESCE(length, 0),
+  COALESCE(scale, 0),
+  CASE WHEN is_nullable = 'TRUE' THEN 'YES' ELSE 'NO' END,
+  COALESCE(default_value, '')
+FROM (
+  SELECT
+    schema_name,
+    table_name,
+    column_name,
+    position,
+    data_type_name,
+    length,
+    scale,
+    is_nullable,
+    default_value
+  FROM SYS.TABLE_COLUMNS
+  UNION ALL
+  SELECT
+    schema_name,
+    view_name,
+    column_name,
+    position,
+    data_type_name,
+    length,
+    scale,
+    is_nullable,
+    default_value
+  FROM SYS.VIEW_COLUMNS
+) c`
+	conds, vals := schemaConds("schema_name", f)
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, "table_name LIKE ?")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "column_name LIKE ?")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "schema_name, table_name, position", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Column
+	for rows.Next() {
+		rec := metadata.Column{NumPrecRadix: 10}
+		if err := rows.Scan(
+			&rec.Schema,
+			&rec.Table,
+			&rec.Name,
+			&rec.OrdinalPosition,
+			&rec.DataType,
+			&rec.ColumnSize,
+			&rec.DecimalDigits,
+			&rec.IsNullable,
+			&rec.Default,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewColumnSet(results), nil
+}
+
+func (r MetadataReader) Indexes(f metadata.Filter) (*metadata.IndexSet, error) {
+	qstr := `SELECT
+  schema_name,
+  table_name,
+  index_name,
+  CASE WHEN constraint = 'PRIMARY KEY' THEN 'YES' ELSE 'NO' END,
+  CASE WHEN constraint IN ('PRIMARY KEY', 'UNIQUE', 'NOT NULL UNIQUE') THEN 'YES' ELSE 'NO' END,
+  index_type
+FROM SYS.INDEXES`
+	conds, vals := indexConds(f)
+	rows, closeRows, err := r.query(qstr, conds, "schema_name, table_name, index_name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Index
+	for rows.Next() {
+		var rec metadata.Index
+		if err := rows.Scan(&rec.Schema, &rec.Table, &rec.Name, &rec.IsPrimary, &rec.IsUnique, &rec.Type); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewIndexSet(results), nil
+}
+
+func (r MetadataReader) IndexColumns(f metadata.Filter) (*metadata.IndexColumnSet, error) {
+	qstr := `SELECT
+  schema_name,
+  table_name,
+  index_name,
+  column_name,
+  position
+FROM SYS.INDEX_COLUMNS`
+	conds, vals := indexConds(f)
+	rows, closeRows, err := r.query(qstr, conds, "schema_name, table_name, index_name, position", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.IndexColumn
+	for rows.Next() {
+		var rec metadata.IndexColumn
+		if err := rows.Scan(&rec.Schema, &rec.Table, &rec.IndexName, &rec.Name, &rec.OrdinalPosition); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewIndexColumnSet(results), nil
+}
+
+// indexConds returns conditions of the filter for SYS.INDEXES and
+// SYS.INDEX_COLUMNS.
+func indexConds(f metadata.Filter) ([]string, []interface{}) {
+	conds, vals := schemaConds("schema_name", f)
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, "table_name LIKE ?")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "index_name LIKE ?")
+	}
+	return conds, vals
+}
+
+func (r MetadataReader) Constraints(f metadata.Filter) (*metadata.ConstraintSet, error) {
+	// primary, unique and check constraints have a row for each column
+	qstr := `SELECT
+  schema_name,
+  table_name,
+  constraint_name,
+  constraint_type,
+  foreign_schema_name,
+  foreign_table_name,
+  foreign_constraint_name,
+  update_rule,
+  delete_rule,
+  check_clause
+FROM (
+  SELECT DISTINCT
+    schema_name,
+    table_name,
+    constraint_name,
+    CASE
+      WHEN is_primary_key = 'TRUE' THEN 'PRIMARY KEY'
+      WHEN is_unique_key = 'TRUE' THEN 'UNIQUE'
+      ELSE 'CHECK'
+    END AS constraint_type,
+    '' AS foreign_schema_name,
+    '' AS foreign_table_name,
+    '' AS foreign_constraint_name,
+    '' AS update_rule,
+    '' AS delete_rule,
+    COALESCE(CAST(check_condition AS NVARCHAR(5000)), '') AS check_clause
+  FROM SYS.CONSTRAINTS
+  UNION ALL
+  SELECT DISTINCT
+    schema_name,
+    table_name,
+    constraint_name,
+    'FOREIGN KEY',
+    referenced_schema_name,
+    referenced_table_name,
+    referenced_constraint_name,
+    update_rule,
+    delete_rule,
+    ''
+  FROM SYS.REFERENTIAL_CONSTRAINTS
+) c`
+	conds, vals := constraintConds(f)
+	rows, closeRows, err := r.query(qstr, conds, "schema_name, table_name, constraint_name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Constraint
+	for rows.Next() {
+		rec := metadata.Constraint{IsDeferrable: metadata.NO, IsInitiallyDeferred: metadata.NO}
+		if err := rows.Scan(
+			&rec.Schema,
+			&rec.Table,
+			&rec.Name,
+			&rec.Type,
+			&rec.ForeignSchema,
+			&rec.ForeignTable,
+			&rec.ForeignName,
+			&rec.UpdateRule,
+			&rec.DeleteRule,
+			&rec.CheckClause,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewConstraintSet(results), nil
+}
+
+func (r MetadataReader) ConstraintColumns(f metadata.Filter) (*metadata.ConstraintColumnSet, error) {
+	qstr := `SELECT
+  schema_name,
+  table_name,
+  constraint_name,
+  column_name,
+  position,
+  foreign_schema_name,
+  foreign_table_name,
+  foreign_constraint_name,
+  foreign_column_name
+FROM (
+  SELECT
+    schema_name,
+    table_name,
+    constraint_name,
+    column_name,
+    position,
+    '' AS foreign_schema_name,
+    '' AS foreign_table_name,
+    '' AS foreign_constraint_name,
+    '' AS foreign_column_name
+  FROM SYS.CONSTRAINTS
+  UNION ALL
+  SELECT
+    schema_name,
+    table_name,
+    constraint_name,
+    column_name,
+    position,
+    referenced_schema_name,
+    referenced_table_name,
+    referenced_constraint_name,
+    referenced_column_name
+  FROM SYS.REFERENTIAL_CONSTRAINTS
+) c`
+	conds, vals := constraintConds(f)
+	rows, closeRows, err := r.query(qstr, conds, "schema_name, table_name, constraint_name, position", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.ConstraintColumn
+	for rows.Next() {
+		var rec metadata.ConstraintColumn
+		if err := rows.Scan(
+			&rec.Schema,
+			&rec.Table,
+			&rec.Constraint,
+			&rec.Name,
+			&rec.OrdinalPosition,
+			&rec.ForeignSchema,
+			&rec.ForeignTable,
+			&rec.ForeignConstraint,
+			&rec.ForeignName,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewConstraintColumnSet(results), nil
+}
+
+// constraintConds returns conditions of the filter for the union of
+// SYS.CONSTRAINTS and SYS.REFERENTIAL_CONSTRAINTS.
+func constraintConds(f metadata.Filter) ([]string, []interface{}) {
+	conds, vals := schemaConds("schema_name", f)
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, "table_name LIKE ?")
+	}
+	if f.Reference != "" {
+		vals = append(vals, f.Reference)
+		conds = append(conds, "foreign_table_name LIKE ?")
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "constraint_name LIKE ?")
+	}
+	return conds, vals
+}
+
+func (r MetadataReader) Sequences(f metadata.Filter) (*metadata.SequenceSet, error) {
+	qstr := `SELECT
+  schema_name,
+  sequence_name,
+  'BIGINT',
+  TO_VARCHAR(start_number),
+  TO_VARCHAR(min_value),
+  TO_VARCHAR(max_value),
+  TO_VARCHAR(increment_by),
+  CASE WHEN is_cycled = 'TRUE' THEN 'YES' ELSE 'NO' END
+FROM SYS.SEQUENCES`
+	conds, vals := schemaConds("schema_name", f)
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "sequence_name LIKE ?")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "schema_name, sequence_name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+	var results []metadata.Sequence
+	for rows.Next() {
+		var rec metadata.Sequence
+		if err := rows.Scan(
+			&rec.Schema,
+			&rec.Name,
+			&rec.DataType,
+			&rec.Start,
+			&rec.Min,
+			&rec.Max,
+			&rec.Increment,
+			&rec.Cycles,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewSequenceSet(results), nil
+}
+
+// schemaConds returns conditions of the filter for the schema column,
+// excluding SYS and _SYS_* schemas unless WithSystem is set.
+func schemaConds(col string, f metadata.Filter) ([]string, []interface{}) {
+	var conds []string
+	var vals []interface{}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, col+" LIKE ?")
+	}
+	if !f.WithSystem {
+		conds = append(conds, col+" <> 'SYS'", col+` NOT LIKE '\_SYS%' ESCAPE '\'`)
+	}
+	if f.OnlyVisible {
+		conds = append(conds, col+" = CURRENT_SCHEMA")
+	}
+	return conds, vals
+}
+
+func (r MetadataReader) query(qstr string, conds []string, order string, vals ...interface{}) (*sql.Rows, func(), error) {
+	if len(conds) != 0 {
+		qstr += "\nWHERE " + strings.Join(conds, " AND ")
+	}
+	if order != "" {
+		qstr += "\nORDER BY " + order
+	}
+	return r.Query(qstr, vals...)
+}
@@ -0,0 +1,120 @@
+package saphana
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/drivers/metadata/metadatatest"
+)
+
+func newReader(t *testing.T) (*MetadataReader, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock := metadatatest.NewDB(t, nil)
+	return NewMetadataReader(db).(*MetadataReader), mock
+}
+
+func TestTables(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM SYS\.TABLES.*FROM SYS\.VIEWS\s+\) t\s+WHERE schema_name LIKE \? AND schema_name <> 'SYS' AND schema_name NOT LIKE '\\_SYS%' ESCAPE '\\' AND table_type IN \(\?\)`).
+		WithArgs("SAKILA", "TABLE").
+		WillReturnRows(sqlmock.NewRows([]string{"schema", "name", "type", "comment"}).
+			AddRow("SAKILA", "FILM", "TABLE", "films"))
+	res, err := r.Tables(metadata.Filter{Schema: "SAKILA", Types: []string{"TABLE"}})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		names = append(names, res.Get().Schema+"."+res.Get().Name+":"+res.Get().Type+":"+res.Get().Comment)
+	}
+	if s, exp := strings.Join(names, ", "), "SAKILA.FILM:TABLE:films"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestTableFilters(t *testing.T) {
+	tests := []struct {
+		filter metadata.Filter
+		where  string
+		args   []driver.Value
+	}{
+		{metadata.Filter{}, `WHERE schema_name <> 'SYS' AND schema_name NOT LIKE '\\_SYS%' ESCAPE '\\'`, nil},
+		{metadata.Filter{WithSystem: true}, ``, nil},
+		{metadata.Filter{OnlyVisible: true, WithSystem: true}, `WHERE schema_name = CURRENT_SCHEMA`, nil},
+		{metadata.Filter{Types: []string{"VIEW", "GLOBAL TEMPORARY"}, WithSystem: true}, `WHERE table_type IN \(\?, \?\)`, []driver.Value{"VIEW", "GLOBAL TEMPORARY"}},
+	}
+	for i, test := range tests {
+		r, mock := newReader(t)
+		mock.ExpectQuery(`FROM SYS\.VIEWS\s+\) t\s+` + test.where + `\s*ORDER BY`).
+			WithArgs(test.args...).
+			WillReturnRows(sqlmock.NewRows([]string{"schema", "name", "type", "comment"}))
+		if _, err := r.Tables(test.filter); err != nil {
+			t.Errorf("test %d expected no error, got: %v", i, err)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM SYS\.TABLE_COLUMNS.*FROM SYS\.VIEW_COLUMNS\s+\) c\s+WHERE .* AND schema_name = CURRENT_SCHEMA AND table_name LIKE \?`).
+		WithArgs("FILM").
+		WillReturnRows(sqlmock.NewRows([]string{"schema", "table", "name", "pos", "type", "size", "scale", "nullable", "default"}).
+			AddRow("SAKILA", "FILM", "FILM_ID", 1, "INTEGER", 10, 0, "NO", "").
+			AddRow("SAKILA", "FILM", "RENTAL_RATE", 2, "DECIMAL", 4, 2, "YES", "4.99"))
+	res, err := r.Columns(metadata.Filter{Parent: "FILM", OnlyVisible: true})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var cols []string
+	for res.Next() {
+		c := res.Get()
+		cols = append(cols, strings.Join([]string{c.Name, c.DataType, string(c.IsNullable), c.Default}, " "))
+	}
+	if s, exp := strings.Join(cols, ", "), "FILM_ID INTEGER NO , RENTAL_RATE DECIMAL YES 4.99"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM SYS\.CONSTRAINTS.*FROM SYS\.REFERENTIAL_CONSTRAINTS\s+\) c\s+WHERE .* AND table_name LIKE \?`).
+		WithArgs("FILM_ACTOR").
+		WillReturnRows(sqlmock.NewRows([]string{"schema", "table", "name", "type", "fschema", "ftable", "fname", "update", "delete", "check"}).
+			AddRow("SAKILA", "FILM_ACTOR", "_SYS_TREE_CS_#1_#0_#P0", "PRIMARY KEY", "", "", "", "", "", "").
+			AddRow("SAKILA", "FILM_ACTOR", "FK_FILM", "FOREIGN KEY", "SAKILA", "FILM", "_SYS_TREE_CS_#2_#0_#P0", "RESTRICT", "CASCADE", ""))
+	res, err := r.Constraints(metadata.Filter{Parent: "FILM_ACTOR"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		c := res.Get()
+		names = append(names, strings.Join([]string{c.Type, c.ForeignTable, c.DeleteRule, string(c.IsDeferrable)}, ":"))
+	}
+	if s, exp := strings.Join(names, ", "), "PRIMARY KEY:::NO, FOREIGN KEY:FILM:CASCADE:NO"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
+
+func TestSequences(t *testing.T) {
+	r, mock := newReader(t)
+	mock.ExpectQuery(`FROM SYS\.SEQUENCES\s+WHERE schema_name LIKE \?`).
+		WithArgs("SAKILA").
+		WillReturnRows(sqlmock.NewRows([]string{"schema", "name", "type", "start", "min", "max", "increment", "cycles"}).
+			AddRow("SAKILA", "FILM_SEQ", "BIGINT", "1", "1", "4611686018427387903", "1", "YES"))
+	res, err := r.Sequences(metadata.Filter{Schema: "SAKILA"})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	var names []string
+	for res.Next() {
+		s := res.Get()
+		names = append(names, s.Name+":"+s.Start+":"+string(s.Cycles))
+	}
+	if s, exp := strings.Join(names, ", "), "FILM_SEQ:1:YES"; s != exp {
+		t.Errorf("expected %q, got: %q", exp, s)
+	}
+}
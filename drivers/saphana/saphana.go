@@ -35,5 +35,6 @@ func init() {
 			}
 			return code, msg
 		},
+		NewMetadataReader: NewMetadataReader,
 	})
 }
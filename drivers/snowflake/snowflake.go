@@ -34,6 +34,7 @@ func init() {
 	)
 	drivers.Register("snowflake", drivers.Driver{
 		AllowMultilineComments: true,
+		AllowBackslashEscapes:  true,
 		Err: func(err error) (string, string) {
 			if e, ok := err.(*gosnowflake.SnowflakeError); ok {
 				return strconv.Itoa(e.Number), e.Message
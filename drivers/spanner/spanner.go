@@ -9,5 +9,8 @@ import (
 )
 
 func init() {
-	drivers.Register("spanner", drivers.Driver{})
+	drivers.Register("spanner", drivers.Driver{
+		AllowBackslashEscapes: true,
+		AllowBackticks:        true,
+	})
 }
@@ -10,12 +10,16 @@ import (
 
 	"github.com/mattn/go-sqlite3" // DRIVER
 	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/plan"
 	"github.com/xo/usql/drivers/sqlite3/sqshared"
 )
 
 func init() {
 	drivers.Register("sqlite3", drivers.Driver{
 		AllowMultilineComments: true,
+		AllowBackticks:         true,
+		AllowBrackets:          true,
+		AllowBlocks:            true,
 		ForceParams: drivers.ForceQueryParameters([]string{
 			"loc", "auto",
 		}),
@@ -40,5 +44,6 @@ func init() {
 		ConvertBytes:      sqshared.ConvertBytes,
 		NewMetadataReader: sqshared.NewMetadataReader,
 		Copy:              drivers.CopyWithInsert(func(int) string { return "?" }),
+		Explain:           plan.SQLite,
 	})
 }
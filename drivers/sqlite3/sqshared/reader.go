@@ -22,11 +22,13 @@ func NewMetadataReader(db drivers.DB, opts ...metadata.ReaderOption) metadata.Re
 }
 
 var (
-	_ metadata.BasicReader          = &MetadataReader{}
-	_ metadata.FunctionReader       = &MetadataReader{}
-	_ metadata.FunctionColumnReader = &MetadataReader{}
-	_ metadata.IndexReader          = &MetadataReader{}
-	_ metadata.IndexColumnReader    = &MetadataReader{}
+	_ metadata.BasicReader           = &MetadataReader{}
+	_ metadata.FunctionReader        = &MetadataReader{}
+	_ metadata.FunctionColumnReader  = &MetadataReader{}
+	_ metadata.IndexReader           = &MetadataReader{}
+	_ metadata.IndexColumnReader     = &MetadataReader{}
+	_ metadata.ExtensionReader       = &MetadataReader{}
+	_ metadata.ExtensionObjectReader = &MetadataReader{}
 )
 
 func (r *MetadataReader) SetLimit(l int) {
@@ -317,6 +319,99 @@ JOIN pragma_index_xinfo(i.name) ic`
 	return metadata.NewIndexColumnSet(results), nil
 }
 
+// Extensions lists virtual table modules and non built-in functions,
+// since SQLite does not keep track of loaded extensions
+func (r MetadataReader) Extensions(f metadata.Filter) (*metadata.ExtensionSet, error) {
+	qstr := `SELECT
+  name,
+  description
+FROM (
+    SELECT
+      name,
+      'virtual table module' AS description
+    FROM pragma_module_list
+    UNION
+    SELECT
+      name,
+      'function' AS description
+    FROM pragma_function_list
+    WHERE builtin = 0
+)`
+	conds := []string{}
+	vals := []interface{}{}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, "name LIKE ?")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "description DESC, name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.Extension{}
+	for rows.Next() {
+		rec := metadata.Extension{}
+		err = rows.Scan(&rec.Name, &rec.Comment)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewExtensionSet(results), nil
+}
+
+// ExtensionObjects lists virtual tables using a module and function overloads
+func (r MetadataReader) ExtensionObjects(f metadata.Filter) (*metadata.ExtensionObjectSet, error) {
+	qstr := `SELECT
+  extension,
+  type,
+  description
+FROM (
+    SELECT
+      l.name AS extension,
+      'table' AS type,
+      'table ' || m.name AS description
+    FROM pragma_module_list l
+    JOIN sqlite_master m ON m.type = 'table' AND m.sql LIKE 'CREATE VIRTUAL TABLE % USING ' || l.name || '%'
+    UNION
+    SELECT
+      name AS extension,
+      'function' AS type,
+      'function ' || name || '(' || CASE WHEN narg < 0 THEN '...' ELSE narg END || ')' AS description
+    FROM pragma_function_list
+    WHERE builtin = 0
+)`
+	conds := []string{}
+	vals := []interface{}{}
+	if f.Parent != "" {
+		vals = append(vals, f.Parent)
+		conds = append(conds, "extension LIKE ?")
+	}
+	rows, closeRows, err := r.query(qstr, conds, "extension, description", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.ExtensionObject{}
+	for rows.Next() {
+		rec := metadata.ExtensionObject{}
+		err = rows.Scan(&rec.Extension, &rec.Type, &rec.Description)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewExtensionObjectSet(results), nil
+}
+
 func (r MetadataReader) query(qstr string, conds []string, order string, vals ...interface{}) (*sql.Rows, func(), error) {
 	if len(conds) != 0 {
 		qstr += "\nWHERE " + strings.Join(conds, " AND ")
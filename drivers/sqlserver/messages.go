@@ -0,0 +1,75 @@
+package sqlserver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/golang-sql/sqlexp"
+	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/text"
+)
+
+// queryMessages executes a statement, returning rows that write messages
+// (ie, PRINT and RAISERROR) received from the database to w.
+func queryMessages(ctx context.Context, db drivers.DB, w io.Writer, sqlstr string) (drivers.MessageRows, error) {
+	msgs := &sqlexp.ReturnMessage{}
+	rows, err := db.QueryContext(ctx, sqlstr, msgs)
+	if err != nil {
+		return nil, err
+	}
+	return &messageRows{
+		Rows: rows,
+		ctx:  ctx,
+		msgs: msgs,
+		w:    w,
+	}, nil
+}
+
+// messageRows are rows that process the message queue of a statement.
+type messageRows struct {
+	*sql.Rows
+	ctx   context.Context
+	msgs  *sqlexp.ReturnMessage
+	w     io.Writer
+	count int64
+	errs  []error
+	done  bool
+}
+
+// NextResultSet processes messages until the next result set is available,
+// writing notices and counts of affected rows in order with result sets.
+func (r *messageRows) NextResultSet() bool {
+	for !r.done {
+		switch m := r.msgs.Message(r.ctx).(type) {
+		case sqlexp.MsgNotice:
+			fmt.Fprintln(r.w, m.Message)
+		case sqlexp.MsgNext:
+			return true
+		case sqlexp.MsgRowsAffected:
+			r.count += m.Count
+			fmt.Fprintf(r.w, text.RowsAffected+"\n", m.Count)
+		case sqlexp.MsgError:
+			r.errs = append(r.errs, m.Error)
+		case sqlexp.MsgNextResultSet:
+			r.done = !r.Rows.NextResultSet()
+		}
+	}
+	return false
+}
+
+// RowsAffected satisfies the drivers.MessageRows interface.
+func (r *messageRows) RowsAffected() int64 {
+	return r.count
+}
+
+// Err returns the errors received from the database.
+func (r *messageRows) Err() error {
+	errs := r.errs[:len(r.errs):len(r.errs)]
+	if err := r.Rows.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
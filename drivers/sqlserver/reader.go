@@ -18,6 +18,7 @@ type metaReader struct {
 var _ metadata.CatalogReader = &metaReader{}
 var _ metadata.IndexReader = &metaReader{}
 var _ metadata.IndexColumnReader = &metaReader{}
+var _ metadata.TypeReader = &metaReader{}
 
 func NewReader(db drivers.DB, opts ...metadata.ReaderOption) metadata.Reader {
 	ir := infos.New(
@@ -225,6 +226,84 @@ JOIN sys.types ty ON ty.user_type_id = c.user_type_id
 	return metadata.NewIndexColumnSet(results), nil
 }
 
+func (r metaReader) Types(f metadata.Filter) (*metadata.TypeSet, error) {
+	const kind = "CASE WHEN t.is_table_type = 1 THEN 'TABLE' WHEN t.is_assembly_type = 1 THEN 'CLR' WHEN t.is_user_defined = 1 THEN 'DOMAIN' ELSE 'BASE' END"
+	qstr := `
+SELECT
+  db_name(),
+  s.name,
+  t.name,
+  ` + kind + `,
+  CASE WHEN t.is_user_defined = 1 AND t.is_table_type = 0 AND t.is_assembly_type = 0 THEN TYPE_NAME(t.system_type_id) ELSE '' END,
+  t.max_length,
+  t.precision,
+  t.scale,
+  CASE WHEN t.is_nullable = 0 THEN 'NOT NULL' ELSE '' END,
+  COALESCE(CAST(ep.value AS nvarchar(4000)), '')
+FROM sys.types t
+JOIN sys.schemas s ON s.schema_id = t.schema_id
+LEFT JOIN sys.extended_properties ep ON ep.class = 6 AND ep.major_id = t.user_type_id AND ep.minor_id = 0 AND ep.name = 'MS_Description'
+`
+	conds := []string{}
+	vals := []interface{}{}
+	if f.OnlyVisible {
+		conds = append(conds, "(s.name = schema_name() OR s.name = 'sys')")
+	}
+	if !f.WithSystem {
+		conds = append(conds, "s.name NOT IN ('db_accessadmin', 'db_backupoperator', 'db_datareader', 'db_datawriter', 'db_ddladmin', 'db_denydatareader', 'db_denydatawriter', 'db_owner', 'db_securityadmin', 'INFORMATION_SCHEMA', 'sys')")
+	}
+	if f.Schema != "" {
+		vals = append(vals, f.Schema)
+		conds = append(conds, fmt.Sprintf("s.name LIKE @p%d", len(vals)))
+	}
+	if f.Name != "" {
+		vals = append(vals, f.Name)
+		conds = append(conds, fmt.Sprintf("t.name LIKE @p%d", len(vals)))
+	}
+	if len(f.Types) != 0 {
+		pholders := []string{"''"}
+		for _, t := range f.Types {
+			vals = append(vals, t)
+			pholders = append(pholders, fmt.Sprintf("@p%d", len(vals)))
+		}
+		conds = append(conds, fmt.Sprintf("%s IN (%s)", kind, strings.Join(pholders, ", ")))
+	}
+	rows, closeRows, err := r.query(qstr, conds, "s.name, t.name", vals...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows()
+
+	results := []metadata.Type{}
+	for rows.Next() {
+		rec := metadata.Type{}
+		var size, precision, scale int
+		err = rows.Scan(&rec.Catalog, &rec.Schema, &rec.Name, &rec.Kind, &rec.BaseType, &size, &precision, &scale, &rec.Constraints, &rec.Comment)
+		if err != nil {
+			return nil, err
+		}
+		if rec.BaseType != "" {
+			col := metadata.Column{DataType: rec.BaseType, ColumnSize: size}
+			switch rec.BaseType {
+			case "nchar", "nvarchar":
+				if size != -1 {
+					col.ColumnSize = size / 2
+				}
+			case "numeric", "decimal":
+				col.ColumnSize, col.DecimalDigits = precision, scale
+			case "datetimeoffset", "datetime2", "time":
+				col.ColumnSize = scale
+			}
+			rec.BaseType = dataTypeFormatter(col)
+		}
+		results = append(results, rec)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return metadata.NewTypeSet(results), nil
+}
+
 func (r metaReader) query(qstr string, conds []string, order string, vals ...interface{}) (*sql.Rows, func(), error) {
 	if len(conds) != 0 {
 		qstr += "\nWHERE " + strings.Join(conds, " AND ")
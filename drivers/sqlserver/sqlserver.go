@@ -6,6 +6,7 @@ package sqlserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -13,12 +14,17 @@ import (
 
 	sqlserver "github.com/microsoft/go-mssqldb" // DRIVER
 	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/completer"
 	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/drivers/plan"
 )
 
 func init() {
 	drivers.Register("sqlserver", drivers.Driver{
 		AllowMultilineComments:  true,
+		AllowBrackets:           true,
+		AllowBlocks:             true,
+		BatchSeparator:          "GO",
 		RequirePreviousPassword: true,
 		LexerName:               "tsql",
 		Version: func(ctx context.Context, db drivers.DB) (string, error) {
@@ -37,7 +43,8 @@ func init() {
 			return err
 		},
 		Err: func(err error) (string, string) {
-			if e, ok := err.(sqlserver.Error); ok {
+			var e sqlserver.Error
+			if errors.As(err, &e) {
 				return strconv.Itoa(int(e.Number)), e.Message
 			}
 			msg := err.Error()
@@ -53,7 +60,29 @@ func init() {
 		NewMetadataWriter: func(db drivers.DB, w io.Writer, opts ...metadata.ReaderOption) metadata.Writer {
 			return metadata.NewDefaultWriter(NewReader(db, opts...))(db, w)
 		},
-		Copy: drivers.CopyWithInsert(placeholder),
+		Copy:          drivers.CopyWithInsert(placeholder),
+		QueryMessages: queryMessages,
+		Explain:       plan.SQLServer,
+		Dialect: completer.Dialect{
+			StartCommands: []string{
+				"BEGIN TRANSACTION",
+				"USE",
+			},
+			Commands: []string{
+				"CROSS APPLY",
+				"OUTER APPLY",
+				"OUTPUT",
+				"TOP",
+			},
+			Clauses: []completer.Clause{
+				{Statement: "SELECT", After: []string{"SELECT"}, Keywords: []string{"DISTINCT", "TOP"}},
+				{Statement: "SELECT", After: []string{"SELECT", "DISTINCT"}, Keywords: []string{"TOP"}},
+				{Statement: "DELETE|INSERT|UPDATE", After: []string{"DELETE|INSERT|UPDATE"}, Keywords: []string{"TOP"}},
+				{Statement: "SELECT", After: []string{"FROM", "*"}, Keywords: []string{"CROSS APPLY", "OUTER APPLY", "WITH (NOLOCK)"}},
+				{Statement: "SELECT", After: []string{"OFFSET", "*"}, Keywords: []string{"ROWS FETCH NEXT"}},
+				{Statement: "DELETE|INSERT|UPDATE", After: []string{"*)"}, Keywords: []string{"OUTPUT"}},
+			},
+		},
 	})
 }
 
@@ -23,6 +23,7 @@ func init() {
 	drivers.Register("vertica", drivers.Driver{
 		AllowDollar:            true,
 		AllowMultilineComments: true,
+		AllowEStrings:          true,
 		Version: func(ctx context.Context, db drivers.DB) (string, error) {
 			var ver string
 			if err := db.QueryRowContext(ctx, `SELECT version()`).Scan(&ver); err != nil {
@@ -30,6 +30,14 @@ var varNames = []varName{
 		"ECHO_HIDDEN",
 		"if set, display internal queries executed by backslash commands; if set to \"noexec\", just show them without execution",
 	},
+	{
+		"NOTIFY_CHANNEL",
+		"channel of the last notification received by \\wait",
+	},
+	{
+		"NOTIFY_PAYLOAD",
+		"payload of the last notification received by \\wait",
+	},
 	{
 		"ON_ERROR_STOP",
 		"stop batch execution after error",
@@ -46,6 +54,18 @@ var varNames = []varName{
 		"ROW_COUNT",
 		"number of rows returned or affected by last query, or 0",
 	},
+	{
+		"SERVEROUTPUT",
+		"display output of DBMS_OUTPUT after each statement (Oracle only)",
+	},
+	{
+		"SHOW_WARNINGS",
+		"display warnings raised by each statement (MySQL only)",
+	},
+	{
+		"WARNING_COUNT",
+		"number of warnings raised by last query, when SHOW_WARNINGS is set (MySQL only)",
+	},
 }
 
 var pvarNames = []varName{
@@ -311,7 +331,7 @@ func Set(name, value string) error {
 	if err := ValidIdentifier(name); err != nil {
 		return err
 	}
-	if name == "ON_ERROR_STOP" || name == "QUIET" {
+	if name == "ON_ERROR_STOP" || name == "QUIET" || name == "SERVEROUTPUT" || name == "SHOW_WARNINGS" {
 		if value == "" {
 			value = "on"
 		} else {
@@ -21,6 +21,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 	"unicode"
@@ -34,6 +35,7 @@ import (
 	"github.com/xo/usql/drivers"
 	"github.com/xo/usql/drivers/completer"
 	"github.com/xo/usql/drivers/metadata"
+	"github.com/xo/usql/drivers/plan"
 	"github.com/xo/usql/env"
 	"github.com/xo/usql/metacmd"
 	"github.com/xo/usql/rline"
@@ -72,6 +74,17 @@ type Handler struct {
 	u  *dburl.URL
 	db *sql.DB
 	tx *sql.Tx
+	// session of the executing statement
+	sess drivers.DB
+	// cache of metadata used by the completer
+	cache *metadata.CachingReader
+	// connections and readers of other databases, used by the completer and
+	// guarded by connMu, since connections are opened in the background
+	connMu  sync.Mutex
+	conns   map[string]*sql.DB
+	readers map[string]metadata.Reader
+	// listener for asynchronous notifications
+	listener drivers.Listener
 	// out file or pipe
 	out io.WriteCloser
 }
@@ -321,6 +334,11 @@ func (h *Handler) Run() error {
 		}
 		// execute buf
 		if execute || h.buf.Ready() || opt.Exec != metacmd.ExecNone {
+			// repeat count given to the batch separator (ie, GO 5)
+			repeat := 1
+			if h.buf.Repeat > 1 {
+				repeat = h.buf.Repeat
+			}
 			// intercept batch query
 			if h.u != nil {
 				typ, end, batch := drivers.IsBatchQueryPrefix(h.u, h.buf.Prefix)
@@ -374,7 +392,10 @@ func (h *Handler) Run() error {
 					out = h.out
 				}
 				ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
-				if err = h.Execute(ctx, out, opt, h.lastPrefix, h.last, forceBatch); err != nil {
+				for i := 0; i < repeat && err == nil; i++ {
+					err = h.Execute(ctx, out, opt, h.lastPrefix, h.last, forceBatch)
+				}
+				if err != nil {
 					lastErr = WrapErr(h.last, err)
 					if env.All()["ON_ERROR_STOP"] == "on" {
 						if iactive {
@@ -419,8 +440,27 @@ func (h *Handler) Execute(ctx context.Context, w io.Writer, opt metacmd.Option,
 		f = h.execSet
 	case metacmd.ExecWatch:
 		f = h.execWatch
+	case metacmd.ExecExplain:
+		f = h.execExplain
+	}
+	release, err := h.session(ctx)
+	if err == nil {
+		defer release()
+		err = drivers.WrapErr(h.u.Driver, f(ctx, w, opt, prefix, sqlstr, qtyp))
+		// write output of the statement, even if it failed part way
+		if outErr := drivers.Output(ctx, h.u, h.DB(), w); err == nil {
+			err = outErr
+		}
+		// write warnings raised by the statement
+		if err == nil && env.All()["SHOW_WARNINGS"] == "on" && drivers.HasWarnings(h.u) {
+			err = h.warnings(ctx)
+		}
+	}
+	// invalidate cached metadata, even if the statement failed part way
+	if h.cache != nil && drivers.IsDDLPrefix(prefix) {
+		h.cache.Invalidate()
 	}
-	if err = drivers.WrapErr(h.u.Driver, f(ctx, w, opt, prefix, sqlstr, qtyp)); err != nil {
+	if err != nil {
 		if forceTrans {
 			defer h.tx.Rollback()
 			h.tx = nil
@@ -433,6 +473,31 @@ func (h *Handler) Execute(ctx context.Context, w io.Writer, opt metacmd.Option,
 	return nil
 }
 
+// session starts the session of a statement, using a single connection when
+// required by the driver, until released. Nested statements, like those of
+// \gexec, use their own session, as rows of the parent are still being read.
+func (h *Handler) session(ctx context.Context) (func(), error) {
+	var db drivers.DB = h.db
+	if h.tx != nil {
+		db = h.tx
+	}
+	sess, release, err := drivers.Session(ctx, h.u, db)
+	if err != nil {
+		return nil, err
+	}
+	prev := h.sess
+	h.sess = sess
+	f := func() {
+		h.sess = prev
+		_ = release()
+	}
+	if err := drivers.Setup(ctx, h.u, h.DB()); err != nil {
+		f()
+		return nil, err
+	}
+	return f, nil
+}
+
 // Reset resets the handler's query statement buffer.
 func (h *Handler) Reset(r []rune) {
 	h.buf.Reset(r)
@@ -473,7 +538,8 @@ func (h *Handler) Reset(r []rune) {
 //
 //	%R - In prompt 1 normally =, but @ if the session is in an inactive branch
 //	of a conditional block, or ^ if in single-line mode, or ! if the session is
-//	disconnected from the database (which can happen if \connect fails). In
+//	disconnected from the database (which can happen if \connect fails), or
+//	the statement terminator when changed with DELIMITER (MySQL only). In
 //	prompt 2 %R is replaced by a character that depends on why psql expects
 //	more input: - if the command simply wasn't terminated yet, but * if there
 //	is an unfinished /* ... */ comment, a single quote if there is an
@@ -653,8 +719,11 @@ func (h *Handler) URL() *dburl.URL {
 
 // DB returns the sql.DB for the handler.
 func (h *Handler) DB() drivers.DB {
-	if h.tx != nil {
+	switch {
+	case h.tx != nil:
 		return h.tx
+	case h.sess != nil:
+		return h.sess
 	}
 	return h.db
 }
@@ -707,7 +776,11 @@ func (h *Handler) Open(ctx context.Context, params ...string) error {
 	// build a list of all possible connStrings for the completer
 	connStrings := h.connStrings()
 	if len(params) == 0 || params[0] == "" {
-		h.l.Completer(completer.NewDefaultCompleter(completer.WithConnStrings(connStrings)))
+		h.l.Completer(completer.NewDefaultCompleter(
+			completer.WithConnStrings(connStrings),
+			completer.WithArgKinds(argKinds()),
+			completer.WithConnReader(h.connReader),
+		))
 		return nil
 	}
 	if h.tx != nil {
@@ -754,7 +827,17 @@ func (h *Handler) Open(ctx context.Context, params ...string) error {
 	// force error/check connection
 	if err == nil {
 		if err = drivers.Ping(ctx, h.u, h.db); err == nil {
-			h.l.Completer(drivers.NewCompleter(ctx, h.u, h.db, readerOpts(), completer.WithConnStrings(connStrings)))
+			opts := []completer.Option{
+				completer.WithConnStrings(connStrings),
+				completer.WithArgKinds(argKinds()),
+				completer.WithConnReader(h.connReader),
+				completer.WithHints(h.l.Hints()),
+			}
+			if h.cache = drivers.NewCachingReader(ctx, h.u, h.db, readerOpts()); h.cache != nil {
+				opts = append(opts, completer.WithReader(h.cache))
+				h.warm()
+			}
+			h.l.Completer(drivers.NewCompleter(ctx, h.u, h.db, readerOpts(), opts...))
 			return h.Version(ctx)
 		}
 	}
@@ -860,10 +943,20 @@ func (h *Handler) Close() error {
 	if h.tx != nil {
 		return text.ErrPreviousTransactionExists
 	}
+	h.connMu.Lock()
+	for _, db := range h.conns {
+		db.Close()
+	}
+	h.conns, h.readers = nil, nil
+	h.connMu.Unlock()
+	if h.listener != nil {
+		h.listener.Close()
+		h.listener = nil
+	}
 	if h.db != nil {
 		err := h.db.Close()
 		drv := h.u.Driver
-		h.db, h.u = nil, nil
+		h.db, h.u, h.cache = nil, nil, nil
 		return drivers.WrapErr(drv, err)
 	}
 	return nil
@@ -1009,13 +1102,41 @@ func (h *Handler) execWatch(ctx context.Context, w io.Writer, opt metacmd.Option
 func (h *Handler) execSingle(ctx context.Context, w io.Writer, opt metacmd.Option, prefix, sqlstr string, qtyp bool) error {
 	// exec or query
 	f := h.exec
-	if qtyp {
+	switch {
+	case drivers.IsCopyIO(h.u, prefix, sqlstr):
+		f = h.copyIO
+	case drivers.HasQueryMessages(h.u):
+		f = h.queryMessages
+	case qtyp:
 		f = h.query
 	}
 	// exec
 	return f(ctx, w, opt, prefix, sqlstr)
 }
 
+// warnings writes the warnings raised by the last executed statement to
+// stderr, setting WARNING_COUNT.
+func (h *Handler) warnings(ctx context.Context) error {
+	count, warnings, err := drivers.Warnings(ctx, h.u, h.DB())
+	if err != nil {
+		_ = env.Set("WARNING_COUNT", "0")
+		return err
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(h.l.Stderr(), text.WarningMessage+"\n", w.Level, w.Code, w.Message)
+	}
+	return env.Set("WARNING_COUNT", strconv.FormatInt(count, 10))
+}
+
+// execExplain explains a SQL query, writing the query plan as a tree.
+func (h *Handler) execExplain(ctx context.Context, w io.Writer, opt metacmd.Option, _, sqlstr string, _ bool) error {
+	n, err := drivers.Explain(ctx, h.u, h.DB(), sqlstr, opt.Params["analyze"] == "true")
+	if err != nil {
+		return err
+	}
+	return plan.Render(w, n)
+}
+
 // execSet executes a SQL query, setting all returned columns as variables.
 func (h *Handler) execSet(ctx context.Context, w io.Writer, opt metacmd.Option, prefix, sqlstr string, _ bool) error {
 	// query
@@ -1085,6 +1206,42 @@ func (h *Handler) query(ctx context.Context, w io.Writer, opt metacmd.Option, ty
 		return err
 	}
 	defer rows.Close()
+	return h.encode(w, opt, typ, rows, start)
+}
+
+// queryMessages executes a statement or query against the database, writing
+// informational messages from the database in order with any result sets.
+func (h *Handler) queryMessages(ctx context.Context, w io.Writer, opt metacmd.Option, typ, sqlstr string) error {
+	start := time.Now()
+	rows, err := drivers.QueryMessages(ctx, h.u, h.DB(), w, sqlstr)
+	if err != nil {
+		_ = env.Set("ROW_COUNT", "0")
+		return err
+	}
+	defer rows.Close()
+	// encode result sets, if any
+	results := rows.NextResultSet()
+	if results {
+		if err := h.encode(w, opt, typ, rows, start); err != nil {
+			_ = env.Set("ROW_COUNT", "0")
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = env.Set("ROW_COUNT", "0")
+		return drivers.WrapErr(h.u.Driver, err)
+	}
+	// print name when there were no result sets, as counts of affected rows
+	// were written with messages
+	if !results {
+		fmt.Fprintln(w, typ)
+	}
+	return env.Set("ROW_COUNT", strconv.FormatInt(rows.RowsAffected(), 10))
+}
+
+// encode encodes the result sets of a query to the writer.
+func (h *Handler) encode(w io.Writer, opt metacmd.Option, typ string, rows tblfmt.ResultSet, start time.Time) error {
+	var err error
 	params := env.Pall()
 	params["time"] = env.GoTime()
 	for k, v := range opt.Params {
@@ -1098,12 +1255,7 @@ func (h *Handler) query(ctx context.Context, w io.Writer, opt metacmd.Option, ty
 			params["expanded"] = "off"
 		}
 		if pipeName != "" {
-			if pipeName[0] == '|' {
-				pipe, cmd, err = env.Pipe(pipeName[1:])
-			} else {
-				pipe, err = os.OpenFile(pipeName, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0o644)
-			}
-			if err != nil {
+			if pipe, cmd, err = openPipe(pipeName); err != nil {
 				return err
 			}
 			w = pipe
@@ -1113,7 +1265,7 @@ func (h *Handler) query(ctx context.Context, w io.Writer, opt metacmd.Option, ty
 	}
 	useColumnTypes := drivers.UseColumnTypes(h.u)
 	// wrap query with crosstab
-	resultSet := tblfmt.ResultSet(rows)
+	resultSet := rows
 	if opt.Exec == metacmd.ExecCrosstab {
 		var err error
 		resultSet, err = tblfmt.NewCrosstabView(rows, tblfmt.WithParams(opt.Crosstab...), tblfmt.WithUseColumnTypes(useColumnTypes))
@@ -1161,6 +1313,19 @@ func (h *Handler) query(ctx context.Context, w io.Writer, opt metacmd.Option, ty
 	return err
 }
 
+// openPipe opens the file, or starts the command when prefixed with |, that
+// results are written to (\g file, \g |command).
+func openPipe(name string) (io.WriteCloser, *exec.Cmd, error) {
+	if name[0] == '|' {
+		return env.Pipe(name[1:])
+	}
+	f, err := os.OpenFile(name, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, nil, nil
+}
+
 // execRows executes all the columns in the row.
 func (h *Handler) execRows(ctx context.Context, w io.Writer, rows *sql.Rows) error {
 	// get columns
@@ -1266,6 +1431,78 @@ func (h *Handler) exec(ctx context.Context, w io.Writer, _ metacmd.Option, typ,
 	return env.Set("ROW_COUNT", strconv.FormatInt(count, 10))
 }
 
+// copyIO passes a COPY ... TO STDOUT or COPY ... FROM STDIN statement through
+// to the database, writing copied data to w, or the file or command of \g, or
+// reading copied data from the lines following the statement.
+func (h *Handler) copyIO(ctx context.Context, w io.Writer, opt metacmd.Option, typ, sqlstr string) error {
+	if pipeName := opt.Params["pipe"]; pipeName != "" {
+		pipe, cmd, err := openPipe(pipeName)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			pipe.Close()
+			if cmd != nil {
+				cmd.Wait()
+			}
+		}()
+		w = pipe
+	}
+	r := &copyReader{h: h}
+	count, err := drivers.CopyIO(ctx, h.u, h.DB(), sqlstr, r, w)
+	if err != nil {
+		// skip the remaining data, like psql, so it is not executed as
+		// statements, but do not prompt for data when the statement failed
+		// before reading it
+		if copyInRE.MatchString(sqlstr) && (r.read || !h.l.Interactive()) {
+			_, _ = io.Copy(io.Discard, r)
+		}
+		_ = env.Set("ROW_COUNT", "0")
+		return err
+	}
+	h.Print("%s %d", typ, count)
+	return env.Set("ROW_COUNT", strconv.FormatInt(count, 10))
+}
+
+// copyInRE matches COPY statements reading from standard input.
+var copyInRE = regexp.MustCompile(`(?is)\bFROM\s+STDIN\b`)
+
+// copyReader reads COPY ... FROM STDIN data from the lines following the
+// statement, until a line containing only \. or EOF.
+type copyReader struct {
+	h    *Handler
+	buf  []byte
+	read bool
+	done bool
+}
+
+// Read satisfies the io.Reader interface.
+func (r *copyReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if !r.read && r.h.l.Interactive() {
+			fmt.Fprintln(r.h.l.Stdout(), text.CopyInInstructions)
+		}
+		if r.read = true; r.h.l.Interactive() {
+			r.h.l.Prompt(text.CopyInPrompt)
+		}
+		line, err := r.h.buf.ReadLine()
+		switch {
+		case err == io.EOF || err == nil && strings.TrimSpace(string(line)) == `\.`:
+			r.done = true
+			continue
+		case err != nil:
+			return 0, err
+		}
+		r.buf = append(append(r.buf, string(line)...), '\n')
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
 // Begin begins a transaction.
 func (h *Handler) Begin(txOpts *sql.TxOptions) error {
 	return h.BeginTx(context.Background(), txOpts)
@@ -1382,6 +1619,151 @@ func (h *Handler) MetadataWriter(ctx context.Context) (metadata.Writer, error) {
 	return drivers.NewMetadataWriter(ctx, h.u, h.db, h.l.Stdout(), readerOpts()...)
 }
 
+// Refresh invalidates cached metadata for the current connection.
+func (h *Handler) Refresh() error {
+	if h.db == nil {
+		return text.ErrNotConnected
+	}
+	if h.cache != nil {
+		h.cache.Invalidate()
+		h.warm()
+	}
+	return nil
+}
+
+// Listen listens for asynchronous notifications on the channel, creating
+// a listener for the current connection if needed.
+func (h *Handler) Listen(ctx context.Context, channel string) error {
+	if h.db == nil {
+		return text.ErrNotConnected
+	}
+	if h.listener == nil {
+		l, err := drivers.NewListener(ctx, h.u, h.db)
+		if err != nil {
+			return err
+		}
+		h.listener = l
+	}
+	return drivers.WrapErr(h.u.Driver, h.listener.Listen(ctx, channel))
+}
+
+// Unlisten stops listening for asynchronous notifications on the channel, or
+// on all channels when empty.
+func (h *Handler) Unlisten(ctx context.Context, channel string) error {
+	switch {
+	case h.db == nil:
+		return text.ErrNotConnected
+	case h.listener == nil:
+		return nil
+	}
+	return drivers.WrapErr(h.u.Driver, h.listener.Unlisten(ctx, channel))
+}
+
+// Wait waits for the next asynchronous notification.
+func (h *Handler) Wait(ctx context.Context) (*drivers.Notification, error) {
+	switch {
+	case h.db == nil:
+		return nil, text.ErrNotConnected
+	case h.listener == nil:
+		return nil, text.ErrNotListening
+	}
+	n, err := h.listener.Wait(ctx)
+	if err != nil {
+		return nil, drivers.WrapErr(h.u.Driver, err)
+	}
+	return n, nil
+}
+
+// warm populates the metadata cache in the background, when interactive.
+func (h *Handler) warm() {
+	if !h.l.Interactive() {
+		return
+	}
+	// same filter as used by the completer for an empty word
+	go h.cache.Warm(metadata.Filter{Name: "%", OnlyVisible: true})
+}
+
+// completerArgKinds are completer kinds of command argument kinds.
+var completerArgKinds = map[metacmd.ArgKind]completer.ArgKind{
+	metacmd.ArgNone:           completer.ArgNone,
+	metacmd.ArgVariable:       completer.ArgVariable,
+	metacmd.ArgIsolationLevel: completer.ArgIsolationLevel,
+	metacmd.ArgDuration:       completer.ArgDuration,
+	metacmd.ArgTable:          completer.ArgTable,
+	metacmd.ArgColumns:        completer.ArgColumns,
+	metacmd.ArgFormatOption:   completer.ArgFormatOption,
+	metacmd.ArgConnection:     completer.ArgConnection,
+	metacmd.ArgFile:           completer.ArgFile,
+}
+
+// argKinds returns the completer kinds of arguments of commands, by command
+// name or alias.
+func argKinds() map[string][]completer.ArgKind {
+	kinds := make(map[string][]completer.ArgKind)
+	for name, args := range metacmd.ArgKinds() {
+		for _, arg := range args {
+			kinds[name] = append(kinds[name], completerArgKinds[arg])
+		}
+	}
+	return kinds
+}
+
+// connReader returns a metadata reader for a database url, when it is the
+// open connection or one of the known connection strings. Other connections
+// are opened in the background, so their readers are only returned on a
+// later completion. Returns nil when no reader is available.
+func (h *Handler) connReader(dsn string) metadata.Reader {
+	if h.u != nil && h.cache != nil {
+		if u, err := dburl.Parse(dsn); err == nil && u.Driver == h.u.Driver && u.DSN == h.u.DSN {
+			return h.cache
+		}
+	}
+	h.connMu.Lock()
+	defer h.connMu.Unlock()
+	if r, ok := h.readers[dsn]; ok {
+		return r
+	}
+	// never open partial or arbitrary urls while completing
+	names := h.connStrings()
+	if i := sort.SearchStrings(names, dsn); i == len(names) || names[i] != dsn {
+		return nil
+	}
+	if h.readers == nil {
+		h.conns, h.readers = make(map[string]*sql.DB), make(map[string]metadata.Reader)
+	}
+	// remember the connection is pending, or failed, to not retry it
+	h.readers[dsn] = nil
+	go h.openConnReader(dsn)
+	return nil
+}
+
+// openConnReader opens a connection and its metadata reader, for connReader.
+func (h *Handler) openConnReader(dsn string) {
+	u, err := dburl.Parse(dsn)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	db, err := drivers.Open(ctx, u, h.l.Stdout, h.l.Stderr)
+	if err != nil {
+		return
+	}
+	if err := drivers.Ping(ctx, u, db); err != nil {
+		db.Close()
+		return
+	}
+	r := drivers.NewCachingReader(ctx, u, db, readerOpts())
+	h.connMu.Lock()
+	defer h.connMu.Unlock()
+	// closed while opening
+	if _, ok := h.readers[dsn]; !ok || r == nil || h.conns[dsn] != nil {
+		db.Close()
+		return
+	}
+	h.conns[dsn], h.readers[dsn] = db, r
+}
+
 // GetOutput gets the output writer.
 func (h *Handler) GetOutput() io.Writer {
 	if h.out == nil {
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/xo/dburl"
+	"github.com/xo/usql/drivers"
+	"github.com/xo/usql/drivers/completer"
+	"github.com/xo/usql/env"
+	"github.com/xo/usql/metacmd"
+	"github.com/xo/usql/rline"
+	"github.com/xo/usql/text"
+)
+
+func init() {
+	// copies rows until a row that is not a number, like a database
+	// stopping to read data on an error
+	drivers.Register("copytest", drivers.Driver{
+		CopyIO: func(_ context.Context, _ drivers.DB, sqlstr string, r io.Reader, w io.Writer) (int64, error) {
+			if strings.Contains(sqlstr, "TO STDOUT") {
+				_, err := io.WriteString(w, "1\n2\n")
+				return 2, err
+			}
+			if strings.Contains(sqlstr, "missing") {
+				return 0, errors.New(`relation "missing" does not exist`)
+			}
+			var n int64
+			s := bufio.NewScanner(r)
+			for s.Scan() {
+				if strings.Trim(s.Text(), "0123456789") != "" {
+					return n, errors.New("invalid input syntax for type integer")
+				}
+				n++
+			}
+			return n, s.Err()
+		},
+	})
+	// writes a count of affected rows for each statement
+	drivers.Register("messagetest", drivers.Driver{
+		QueryMessages: func(_ context.Context, _ drivers.DB, w io.Writer, sqlstr string) (drivers.MessageRows, error) {
+			return &messageRows{w: w, counts: strings.Split(sqlstr, ";")}, nil
+		},
+	})
+}
+
+// messageRows are rows without result sets, writing a message for each count.
+type messageRows struct {
+	w      io.Writer
+	counts []string
+	count  int64
+}
+
+func (r *messageRows) Next() bool                 { return false }
+func (r *messageRows) Scan(...interface{}) error  { return nil }
+func (r *messageRows) Columns() ([]string, error) { return nil, nil }
+func (r *messageRows) Close() error               { return nil }
+func (r *messageRows) Err() error                 { return nil }
+func (r *messageRows) RowsAffected() int64        { return r.count }
+func (r *messageRows) NextResultSet() bool {
+	for _, s := range r.counts {
+		n, _ := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		r.count += n
+		fmt.Fprintf(r.w, text.RowsAffected+"\n", n)
+	}
+	return false
+}
+
+func TestCopyIO(t *testing.T) {
+	tests := []struct {
+		sqlstr string
+		lines  []string
+		pipe   bool
+		exp    string
+		err    bool
+	}{
+		{"COPY t FROM STDIN", []string{"1", "2", `\.`, "select 1;"}, false, "COPY 2\n", false},
+		{"COPY t FROM STDIN", []string{"1", "x", "2", `\.`, "select 1;"}, false, "", true},
+		{"COPY missing FROM STDIN", []string{"1", "2", `\.`, "select 1;"}, false, "", true},
+		{"COPY t TO STDOUT", []string{"select 1;"}, false, "1\n2\nCOPY 2\n", false},
+		{"COPY t TO STDOUT", []string{"select 1;"}, true, "COPY 2\n", false},
+	}
+	for i, test := range tests {
+		lines := test.lines
+		var out bytes.Buffer
+		h := New(&rline.Rline{
+			N: func() ([]rune, error) {
+				if len(lines) == 0 {
+					return nil, io.EOF
+				}
+				line := lines[0]
+				lines = lines[1:]
+				return []rune(line), nil
+			},
+			Out: &out,
+		}, nil, "", true)
+		h.u = &dburl.URL{Driver: "copytest"}
+		opt := metacmd.Option{Params: map[string]string{}}
+		name := filepath.Join(t.TempDir(), "out.txt")
+		if test.pipe {
+			opt.Params["pipe"] = name
+		}
+		err := h.copyIO(context.Background(), &out, opt, "COPY", test.sqlstr)
+		switch {
+		case test.err && err == nil:
+			t.Errorf("test %d expected error, got nil", i)
+		case !test.err && err != nil:
+			t.Errorf("test %d expected no error, got: %v", i, err)
+		}
+		if s := out.String(); s != test.exp {
+			t.Errorf("test %d expected output %q, got: %q", i, test.exp, s)
+		}
+		if buf, err := os.ReadFile(name); test.pipe && (err != nil || string(buf) != "1\n2\n") {
+			t.Errorf("test %d expected data written to %s, got: %q (%v)", i, name, buf, err)
+		}
+		// the data is skipped, even on error
+		line, err := h.buf.ReadLine()
+		if err != nil {
+			t.Fatalf("test %d expected no error, got: %v", i, err)
+		}
+		if s, exp := string(line), "select 1;"; s != exp {
+			t.Errorf("test %d expected next line %q, got: %q", i, exp, s)
+		}
+	}
+}
+
+func TestConnReader(t *testing.T) {
+	h := New(&rline.Rline{Out: io.Discard}, &user.User{HomeDir: t.TempDir()}, "", true)
+	name := filepath.Join(t.TempDir(), "test.db")
+	for _, dsn := range []string{"sq:" + name, "copytest:" + name, "copytest:/"} {
+		if r := h.connReader(dsn); r != nil {
+			t.Errorf("expected no reader for %q, got: %v", dsn, r)
+		}
+		if _, ok := h.readers[dsn]; ok {
+			t.Errorf("expected %q not to be opened", dsn)
+		}
+	}
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("expected %s not to be created, got: %v", name, err)
+	}
+}
+
+func TestArgKinds(t *testing.T) {
+	for name, args := range metacmd.ArgKinds() {
+		for _, arg := range args {
+			if _, ok := completerArgKinds[arg]; !ok {
+				t.Errorf("expected completer kind of argument %d of \\%s", arg, name)
+			}
+		}
+	}
+	if kinds := argKinds()["copy"]; len(kinds) == 0 || kinds[0] != completer.ArgConnection {
+		t.Errorf("expected \\copy to start with a connection, got: %v", kinds)
+	}
+}
+
+func TestQueryMessages(t *testing.T) {
+	var out bytes.Buffer
+	h := New(&rline.Rline{Out: &out}, nil, "", true)
+	h.u = &dburl.URL{Driver: "messagetest"}
+	if err := h.queryMessages(context.Background(), &out, metacmd.Option{}, "UPDATE", "1; 2"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if s, exp := out.String(), "(1 rows affected)\n(2 rows affected)\nUPDATE\n"; s != exp {
+		t.Errorf("expected output %q, got: %q", exp, s)
+	}
+	if s := env.Get("ROW_COUNT"); s != "3" {
+		t.Errorf("expected ROW_COUNT 3, got: %q", s)
+	}
+}
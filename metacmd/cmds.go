@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -18,6 +19,7 @@ import (
 	"github.com/xo/dburl"
 	"github.com/xo/usql/drivers"
 	"github.com/xo/usql/env"
+	"github.com/xo/usql/rline"
 	"github.com/xo/usql/text"
 )
 
@@ -27,6 +29,8 @@ type Cmd struct {
 	Name    string
 	Desc    Desc
 	Aliases map[string]Desc
+	// Args are kinds of arguments of the command and its aliases, by name.
+	Args    map[string][]ArgKind
 	Process func(*Params) error
 }
 
@@ -139,6 +143,10 @@ func init() {
 				"c":       {"connect to database with driver and parameters", "DRIVER PARAMS..."},
 				"connect": {},
 			},
+			Args: map[string][]ArgKind{
+				"c":       {ArgConnection},
+				"connect": {ArgConnection},
+			},
 			Process: func(p *Params) error {
 				vals, err := p.GetAll(true)
 				if err != nil {
@@ -191,6 +199,13 @@ func init() {
 				"crosstabview": {"execute query and display results in crosstab", "[(OPTIONS)] [COLUMNS]"},
 				"watch":        {"execute query every specified interval", "[(OPTIONS)] [DURATION]"},
 			},
+			Args: map[string][]ArgKind{
+				"g":            {ArgFormatOption, ArgFile},
+				"gx":           {ArgFormatOption, ArgFile},
+				"G":            {ArgFormatOption, ArgFile},
+				"crosstabview": {ArgFormatOption, ArgColumns},
+				"watch":        {ArgFormatOption, ArgDuration},
+			},
 			Process: func(p *Params) error {
 				p.Option.Exec = ExecOnly
 				switch p.Name {
@@ -263,6 +278,10 @@ func init() {
 			Name:    "e",
 			Desc:    Desc{"edit the query buffer (or file) with external editor", "[FILE] [LINE]"},
 			Aliases: map[string]Desc{"edit": {}},
+			Args: map[string][]ArgKind{
+				"e":    {ArgFile},
+				"edit": {ArgFile},
+			},
 			Process: func(p *Params) error {
 				// get last statement
 				s, buf := p.Handler.Last(), p.Handler.Buf()
@@ -377,6 +396,10 @@ func init() {
 			Name:    "w",
 			Desc:    Desc{"write query buffer to file", "FILE"},
 			Aliases: map[string]Desc{"write": {}},
+			Args: map[string][]ArgKind{
+				"w":     {ArgFile},
+				"write": {ArgFile},
+			},
 			Process: func(p *Params) error {
 				// get last statement
 				s, buf := p.Handler.Last(), p.Handler.Buf()
@@ -394,6 +417,9 @@ func init() {
 			Section: SectionOperatingSystem,
 			Name:    "cd",
 			Desc:    Desc{"change the current working directory", "[DIR]"},
+			Args: map[string][]ArgKind{
+				"cd": {ArgFile},
+			},
 			Process: func(p *Params) error {
 				dir, err := p.Get(true)
 				if err != nil {
@@ -463,6 +489,10 @@ func init() {
 			Name:    "o",
 			Desc:    Desc{"send all query results to file or |pipe", "[FILE]"},
 			Aliases: map[string]Desc{"out": {}},
+			Args: map[string][]ArgKind{
+				"o":   {ArgFile},
+				"out": {ArgFile},
+			},
 			Process: func(p *Params) error {
 				if out := p.Handler.GetOutput(); out != nil {
 					p.Handler.SetOutput(nil)
@@ -497,6 +527,12 @@ func init() {
 				"include":          {},
 				"include_relative": {},
 			},
+			Args: map[string][]ArgKind{
+				"i":                {ArgFile},
+				"ir":               {ArgFile},
+				"include":          {ArgFile},
+				"include_relative": {ArgFile},
+			},
 			Process: func(p *Params) error {
 				path, err := p.Get(true)
 				if err != nil {
@@ -519,6 +555,9 @@ func init() {
 				"rollback": {"rollback (abort) current transaction", ""},
 				"abort":    {},
 			},
+			Args: map[string][]ArgKind{
+				"begin": {ArgIsolationLevel},
+			},
 			Process: func(p *Params) error {
 				switch p.Name {
 				case "commit":
@@ -574,6 +613,9 @@ func init() {
 			Section: SectionVariables,
 			Name:    "prompt",
 			Desc:    Desc{"prompt user to set variable", "[-TYPE] <VAR> [PROMPT]"},
+			Args: map[string][]ArgKind{
+				"prompt": {ArgVariable},
+			},
 			Process: func(p *Params) error {
 				typ := "string"
 				ok, n, err := p.GetOptional(true)
@@ -608,6 +650,9 @@ func init() {
 			Section: SectionVariables,
 			Name:    "set",
 			Desc:    Desc{"set internal variable, or list all if no parameters", "[NAME [VALUE]]"},
+			Args: map[string][]ArgKind{
+				"set": {ArgVariable},
+			},
 			Process: func(p *Params) error {
 				ok, n, err := p.GetOK(true)
 				if err != nil {
@@ -639,6 +684,9 @@ func init() {
 			Section: SectionVariables,
 			Name:    "unset",
 			Desc:    Desc{"unset (delete) internal variable", "NAME"},
+			Args: map[string][]ArgKind{
+				"unset": {ArgVariable},
+			},
 			Process: func(p *Params) error {
 				n, err := p.Get(true)
 				if err != nil {
@@ -740,16 +788,27 @@ func init() {
 			Name:    "d[S+]",
 			Desc:    Desc{"list tables, views, and sequences or describe table, view, sequence, or index", "[NAME]"},
 			Aliases: map[string]Desc{
-				"da[S+]": {"list aggregates", "[PATTERN]"},
-				"df[S+]": {"list functions", "[PATTERN]"},
-				"dm[S+]": {"list materialized views", "[PATTERN]"},
-				"dv[S+]": {"list views", "[PATTERN]"},
-				"ds[S+]": {"list sequences", "[PATTERN]"},
-				"dn[S+]": {"list schemas", "[PATTERN]"},
-				"dt[S+]": {"list tables", "[PATTERN]"},
-				"di[S+]": {"list indexes", "[PATTERN]"},
-				"dp[S]":  {"list table, view, and sequence access privileges", "[PATTERN]"},
-				"l[+]":   {"list databases", ""},
+				"da[S+]":  {"list aggregates", "[PATTERN]"},
+				"df[S+]":  {"list functions", "[PATTERN]"},
+				"dm[S+]":  {"list materialized views", "[PATTERN]"},
+				"dv[S+]":  {"list views", "[PATTERN]"},
+				"ds[S+]":  {"list sequences", "[PATTERN]"},
+				"dn[S+]":  {"list schemas", "[PATTERN]"},
+				"dt[S+]":  {"list tables", "[PATTERN]"},
+				"di[S+]":  {"list indexes", "[PATTERN]"},
+				"dT[S+]":  {"list data types", "[PATTERN]"},
+				"dD[S+]":  {"list domains", "[PATTERN]"},
+				"dE[S+]":  {"list foreign tables", "[PATTERN]"},
+				"des[+]":  {"list foreign servers", "[PATTERN]"},
+				"dew[+]":  {"list foreign-data wrappers", "[PATTERN]"},
+				"deu[+]":  {"list user mappings", "[PATTERN]"},
+				"dp[S]":   {"list table, view, and sequence access privileges", "[PATTERN]"},
+				"dx[+]":   {"list extensions", "[PATTERN]"},
+				"dy[S+]":  {"list triggers", "[PATTERN]"},
+				"dP[+]":   {"list partitions of partitioned tables", "[PATTERN]"},
+				"dco[S+]": {"list constraints", "[PATTERN]"},
+				"l[+]":    {"list databases", ""},
+				"sig":     {"show signatures of functions", "NAME"},
 			},
 			Process: func(p *Params) error {
 				ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
@@ -779,10 +838,35 @@ func init() {
 					return m.ListSchemas(p.Handler.URL(), pattern, verbose, showSystem)
 				case "di":
 					return m.ListIndexes(p.Handler.URL(), pattern, verbose, showSystem)
+				case "dT":
+					return m.ListTypes(p.Handler.URL(), pattern, verbose, showSystem)
+				case "dD":
+					return m.ListDomains(p.Handler.URL(), pattern, verbose, showSystem)
+				case "dE":
+					return m.ListForeignTables(p.Handler.URL(), pattern, verbose, showSystem)
+				case "des":
+					return m.ListForeignServers(p.Handler.URL(), pattern, verbose)
+				case "dew":
+					return m.ListForeignDataWrappers(p.Handler.URL(), pattern, verbose)
+				case "deu":
+					return m.ListUserMappings(p.Handler.URL(), pattern, verbose)
+				case "dy":
+					return m.ListTriggers(p.Handler.URL(), pattern, verbose, showSystem)
+				case "dco":
+					return m.ListConstraints(p.Handler.URL(), pattern, verbose, showSystem)
+				case "dP":
+					return m.ListPartitions(p.Handler.URL(), pattern, verbose)
+				case "dx":
+					return m.ListExtensions(p.Handler.URL(), pattern, verbose)
 				case "l":
 					return m.ListAllDbs(p.Handler.URL(), pattern, verbose)
 				case "dp":
 					return m.ListPrivilegeSummaries(p.Handler.URL(), pattern, showSystem)
+				case "sig":
+					if pattern == "" {
+						return text.ErrMissingRequiredArgument
+					}
+					return m.DescribeSignatures(p.Handler.URL(), pattern)
 				}
 				return nil
 			},
@@ -825,6 +909,14 @@ func init() {
 				return m.ShowStats(p.Handler.URL(), name, pattern, verbose, k)
 			},
 		},
+		Refresh: {
+			Section: SectionConnection,
+			Name:    "refresh",
+			Desc:    Desc{"refresh cached database metadata used for completion", ""},
+			Process: func(p *Params) error {
+				return p.Handler.Refresh()
+			},
+		},
 		Copy: {
 			Section: SectionInputOutput,
 			Name:    "copy",
@@ -832,6 +924,9 @@ func init() {
 			Aliases: map[string]Desc{
 				"copy": {"copy query from source url to columns of table on destination url", "SRC DST QUERY TABLE(A,...)"},
 			},
+			Args: map[string][]ArgKind{
+				"copy": {ArgConnection, ArgConnection, ArgNone, ArgTable},
+			},
 			Process: func(p *Params) error {
 				ctx := context.Background()
 				stdout, stderr := p.Handler.IO().Stdout, p.Handler.IO().Stderr
@@ -885,6 +980,90 @@ func init() {
 				return nil
 			},
 		},
+		Explain: {
+			Section: SectionQueryExecute,
+			Name:    "explain",
+			Desc:    Desc{"explain query and show the plan as a tree (analyze executes the query)", "[analyze]"},
+			Process: func(p *Params) error {
+				p.Option.Exec = ExecExplain
+				ok, s, err := p.GetOK(true)
+				switch {
+				case err != nil:
+					return err
+				case ok && !strings.EqualFold(s, "analyze"):
+					return fmt.Errorf(text.InvalidOption, s)
+				case ok:
+					p.Option.Params = map[string]string{"analyze": "true"}
+				}
+				return nil
+			},
+		},
+		Listen: {
+			Section: SectionInputOutput,
+			Name:    "listen",
+			Desc:    Desc{"listen for asynchronous notifications on a channel", "CHANNEL"},
+			Aliases: map[string]Desc{
+				"unlisten": {"stop listening for notifications on a channel (or all channels)", "[CHANNEL]"},
+				"wait":     {"wait for an asynchronous notification (or until timeout)", "[TIMEOUT]"},
+			},
+			Process: func(p *Params) error {
+				ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+				defer cancel()
+				switch p.Name {
+				case "listen":
+					channel, err := p.Get(true)
+					switch {
+					case err != nil:
+						return err
+					case channel == "":
+						return text.ErrWrongNumberOfArguments
+					}
+					return p.Handler.Listen(ctx, channel)
+				case "unlisten":
+					channel, err := p.Get(true)
+					if err != nil {
+						return err
+					}
+					return p.Handler.Unlisten(ctx, channel)
+				}
+				ok, s, err := p.GetOK(true)
+				switch {
+				case err != nil:
+					return err
+				case ok:
+					d, err := time.ParseDuration(s)
+					if err != nil {
+						if f, err := strconv.ParseFloat(s, 64); err == nil {
+							d = time.Duration(f * float64(time.Second))
+						}
+					}
+					if d <= 0 {
+						return text.ErrInvalidWaitTimeout
+					}
+					var timeoutCancel context.CancelFunc
+					ctx, timeoutCancel = context.WithTimeout(ctx, d)
+					defer timeoutCancel()
+				}
+				n, err := p.Handler.Wait(ctx)
+				switch {
+				case errors.Is(err, context.DeadlineExceeded):
+					return text.ErrNoNotification
+				case errors.Is(err, context.Canceled):
+					return rline.ErrInterrupt
+				case err != nil:
+					return err
+				}
+				var payload string
+				if n.Payload != "" {
+					payload = fmt.Sprintf(text.NotificationPayload, n.Payload)
+				}
+				p.Handler.Print(text.NotificationReceived, n.Channel, payload, n.PID)
+				if err := env.Set("NOTIFY_CHANNEL", n.Channel); err != nil {
+					return err
+				}
+				return env.Set("NOTIFY_PAYLOAD", n.Payload)
+			},
+		},
 	}
 	// set up map
 	cmdMap = make(map[string]Metacmd, len(cmds))
@@ -28,6 +28,18 @@ func Decode(name string, params *stmt.Params) (Runner, error) {
 	}), nil
 }
 
+// ArgKinds returns the kinds of arguments of commands, by command name or
+// alias.
+func ArgKinds() map[string][]ArgKind {
+	kinds := make(map[string][]ArgKind)
+	for _, cmd := range cmds {
+		for name, k := range cmd.Args {
+			kinds[name] = k
+		}
+	}
+	return kinds
+}
+
 // Command types.
 const (
 	// None is an empty command.
@@ -88,4 +100,10 @@ const (
 	Timing
 	// Stats is the show stats meta command (\ss and variants).
 	Stats
+	// Refresh is the refresh metadata cache meta command (\refresh).
+	Refresh
+	// Listen is the asynchronous notification meta command (\listen, \unlisten, \wait).
+	Listen
+	// Explain is the query plan meta command (\explain).
+	Explain
 )
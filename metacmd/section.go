@@ -65,7 +65,12 @@ func Listing(w io.Writer) {
 				aliases = append(aliases, alias)
 			}
 			sort.Slice(aliases, func(i, j int) bool {
-				return strings.ToLower(aliases[i]) < strings.ToLower(aliases[j])
+				a, b := strings.ToLower(aliases[i]), strings.ToLower(aliases[j])
+				if a == b {
+					// list lowercase variants, like \dt, before uppercase ones, like \dT
+					return aliases[i] > aliases[j]
+				}
+				return a < b
 			})
 			for _, alias := range aliases {
 				s, opts := optText(cmd.Aliases[alias])
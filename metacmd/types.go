@@ -63,6 +63,14 @@ type Handler interface {
 	SetOutput(io.WriteCloser)
 	// MetadataWriter retrieves the metadata writer for the handler.
 	MetadataWriter(context.Context) (metadata.Writer, error)
+	// Refresh invalidates cached metadata for the current connection.
+	Refresh() error
+	// Listen listens for notifications on a channel.
+	Listen(context.Context, string) error
+	// Unlisten stops listening for notifications on a channel.
+	Unlisten(context.Context, string) error
+	// Wait waits for the next notification.
+	Wait(context.Context) (*drivers.Notification, error)
 	// Print formats according to a format specifier and writes to handler's standard output.
 	Print(string, ...interface{})
 }
@@ -99,6 +107,33 @@ const (
 	ExecCrosstab
 	// ExecWatch indicates repeated execution with a fixed time interval.
 	ExecWatch
+	// ExecExplain indicates explaining the query plan (\explain).
+	ExecExplain
+)
+
+// ArgKind is the kind of an argument of a command, used for completion.
+type ArgKind int
+
+const (
+	// ArgNone is an argument that is not completed.
+	ArgNone ArgKind = iota
+	// ArgVariable is the name of a variable.
+	ArgVariable
+	// ArgIsolationLevel is a transaction isolation level.
+	ArgIsolationLevel
+	// ArgDuration is a duration.
+	ArgDuration
+	// ArgTable is a table, on the connection of the last preceding
+	// ArgConnection, if any.
+	ArgTable
+	// ArgColumns is a list of columns of tables referenced by the query.
+	ArgColumns
+	// ArgFormatOption is a parenthesized list of format options (\g (format=csv)).
+	ArgFormatOption
+	// ArgConnection is a database url or connection string.
+	ArgConnection
+	// ArgFile is a file name.
+	ArgFile
 )
 
 // Option contains parsed result options of a metacmd.
@@ -27,6 +27,8 @@ type IO interface {
 	Stdout() io.Writer
 	// Stderr is the IO's standard error out.
 	Stderr() io.Writer
+	// Hints is the IO's writer for hints shown while reading a line.
+	Hints() io.Writer
 	// Interactive determines if the IO is an interactive terminal.
 	Interactive() bool
 	// Cygwin determines if the IO is a Cygwin interactive terminal.
@@ -50,6 +52,7 @@ type Rline struct {
 	C    func() error
 	Out  io.Writer
 	Err  io.Writer
+	Hint io.Writer
 	Int  bool
 	Cyg  bool
 	P    func(string)
@@ -84,6 +87,11 @@ func (l *Rline) Stderr() io.Writer {
 	return l.Err
 }
 
+// Hints is the IO's writer for hints shown while reading a line.
+func (l *Rline) Hints() io.Writer {
+	return l.Hint
+}
+
 // Interactive determines if the IO is an interactive terminal.
 func (l *Rline) Interactive() bool {
 	return l.Int
@@ -204,6 +212,11 @@ func New(forceNonInteractive bool, out, histfile string) (IO, error) {
 	if forceNonInteractive {
 		n, pw = nil, nil
 	}
+	// hints are written through readline, which redraws the prompt
+	var hint io.Writer
+	if interactive && !cygwin {
+		hint = l.Stdout()
+	}
 	return &Rline{
 		Inst: l,
 		N:    n,
@@ -213,11 +226,12 @@ func New(forceNonInteractive bool, out, histfile string) (IO, error) {
 			}
 			return nil
 		},
-		Out: stdout,
-		Err: stderr,
-		Int: interactive || cygwin,
-		Cyg: cygwin,
-		P:   l.SetPrompt,
+		Out:  stdout,
+		Err:  stderr,
+		Hint: hint,
+		Int:  interactive || cygwin,
+		Cyg:  cygwin,
+		P:    l.SetPrompt,
 		A: func(a readline.AutoCompleter) {
 			cfg := l.Config.Clone()
 			cfg.AutoComplete = a
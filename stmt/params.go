@@ -46,7 +46,7 @@ loop:
 		switch {
 		case quote != 0:
 			start := i - 1
-			i, ok = readString(p.R, i, p.Len, quote, "")
+			i, ok = readString(p.R, i, p.Len, quote, "", true)
 			if !ok {
 				break loop
 			}
@@ -2,6 +2,8 @@ package stmt
 
 import (
 	"regexp"
+	"strconv"
+	"strings"
 	"unicode"
 )
 
@@ -52,6 +54,107 @@ func isEmptyLine(r []rune, i, end int) bool {
 	return !ok
 }
 
+// readBatchSeparator reads a batch separator (ie, GO) alone on the line in r,
+// followed by an optional repeat count and sql comment, returning the repeat
+// count and whether the line is a batch separator.
+func readBatchSeparator(r []rune, end int, sep string) (int, bool) {
+	i, ok := findNonSpace(r, 0, end)
+	if !ok || end-i < len(sep) || !strings.EqualFold(string(r[i:i+len(sep)]), sep) {
+		return 0, false
+	}
+	i += len(sep)
+	if i < end && !unicode.IsSpace(r[i]) && r[i] != '-' {
+		return 0, false
+	}
+	// repeat count
+	i, _ = findNonSpace(r, i, end)
+	n, start := 1, i
+	for ; i < end && '0' <= r[i] && r[i] <= '9'; i++ {
+	}
+	if start != i {
+		var err error
+		if n, err = strconv.Atoi(string(r[start:i])); err != nil || n < 1 {
+			return 0, false
+		}
+	}
+	// trailing comment
+	i, ok = findNonSpace(r, i, end)
+	if ok && (r[i] != '-' || grab(r, i+1, end) != '-') {
+		return 0, false
+	}
+	return n, true
+}
+
+// isSlashLine returns true when r is composed of only a slash (ie, /) and
+// whitespace.
+func isSlashLine(r []rune, end int) bool {
+	i, ok := findNonSpace(r, 0, end)
+	return ok && r[i] == '/' && isEmptyLine(r, i+1, end)
+}
+
+// plsqlBlockRE is a regexp that matches the prefix of PL/SQL blocks.
+var plsqlBlockRE = regexp.MustCompile(`^(DECLARE|BEGIN|CREATE (OR REPLACE )?((NON)?EDITIONABLE )?(FUNCTION|PROCEDURE|PACKAGE|TRIGGER|TYPE))\b`)
+
+// IsPLSQLBlock returns true when the prefix is the start of a PL/SQL block
+// (ie, BEGIN, DECLARE, CREATE PROCEDURE), that is terminated only by a line
+// containing a slash (ie, /).
+func IsPLSQLBlock(prefix string) bool {
+	return plsqlBlockRE.MatchString(prefix)
+}
+
+// readDelimiter reads a delimiter command (ie, DELIMITER $$) in r, returning
+// the new statement delimiter and whether the line is a delimiter command.
+func readDelimiter(r []rune, end int) (string, bool) {
+	const cmd = "DELIMITER"
+	i, ok := findNonSpace(r, 0, end)
+	if !ok || end-i <= len(cmd) || !strings.EqualFold(string(r[i:i+len(cmd)]), cmd) || !unicode.IsSpace(r[i+len(cmd)]) {
+		return "", false
+	}
+	start, ok := findNonSpace(r, i+len(cmd), end)
+	if !ok {
+		return "", false
+	}
+	i, _ = findSpace(r, start, end)
+	return string(r[start:i]), true
+}
+
+// hasPrefix returns true when r, starting at i, begins with s.
+func hasPrefix(r []rune, i, end int, s string) bool {
+	for _, c := range s {
+		if i >= end || r[i] != c {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// isWordRune returns true when c is part of a word (ie, a keyword or
+// identifier).
+func isWordRune(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// readWord reads the word in r starting at i, returning the upper cased word
+// and its end position.
+func readWord(r []rune, i, end int) (string, int) {
+	start := i
+	for ; i < end && isWordRune(r[i]); i++ {
+	}
+	return strings.ToUpper(string(r[start:i])), i
+}
+
+// blockStmtRE is a regexp that matches the prefix of statements that can
+// contain compound statement blocks (ie, BEGIN ... END).
+var blockStmtRE = regexp.MustCompile(`^(CREATE|ALTER) (OR (REPLACE|ALTER) )?(DEFINER\b|((TEMP|TEMPORARY|AGGREGATE) )?(TRIGGER|PROCEDURE|PROC|FUNCTION|EVENT)\b)`)
+
+// IsBlockStmt returns true when the prefix is the start of a statement that
+// can contain compound statement blocks (ie, CREATE TRIGGER ... BEGIN ...
+// END).
+func IsBlockStmt(prefix string) bool {
+	return blockStmtRE.MatchString(prefix)
+}
+
 // identifierRE is a regexp that matches dollar tag identifiers ($tag$).
 var identifierRE = regexp.MustCompile(`(?i)^[a-z_][a-z0-9_]{0,127}$`)
 
@@ -83,24 +186,34 @@ func readDollarAndTag(r []rune, i, end int) (string, int, bool) {
 // readString seeks to the end of a string returning the position and whether
 // or not the string's end was found.
 //
+// The quote is one of ', ", `, $ (dollar quoted string with tag), [ (bracket
+// identifier), q (alternative quoted string, with tag as the closing
+// delimiter), or E (escape string). Backslash escapes are allowed in '
+// strings when escapes is true, and always in E strings.
+//
 // If the string's terminator was not found, then the result will be the passed
 // end.
-func readString(r []rune, i, end int, quote rune, tag string) (int, bool) {
+func readString(r []rune, i, end int, quote rune, tag string, escapes bool) (int, bool) {
 	var prev, c, next rune
 	for ; i < end; i++ {
 		c, next = r[i], grab(r, i+1, end)
 		switch {
-		case quote == '\'' && c == '\\':
+		case c == '\\' && (quote == 'E' || escapes && quote == '\''):
 			i++
 			prev = 0
 			continue
-		case quote == '\'' && c == '\'' && next == '\'':
+		case (quote == '\'' || quote == 'E') && c == '\'' && next == '\'',
+			quote == '[' && c == ']' && next == ']':
 			i++
 			continue
 		case quote == '\'' && c == '\'' && prev != '\'',
+			quote == 'E' && c == '\'',
 			quote == '"' && c == '"',
-			quote == '`' && c == '`':
+			quote == '`' && c == '`',
+			quote == '[' && c == ']':
 			return i, true
+		case quote == 'q' && next == '\'' && string(c) == tag:
+			return i + 1, true
 		case quote == '$' && c == '$':
 			if id, pos, ok := readDollarAndTag(r, i, end); ok && tag == id {
 				return pos, true
@@ -111,6 +224,37 @@ func readString(r []rune, i, end int, quote rune, tag string) (int, bool) {
 	return end, false
 }
 
+// readQQuote reads the start of an alternative quoted string (ie, q'[ or
+// nq'{) in r at i, returning the closing delimiter and the position of the
+// opening delimiter.
+func readQQuote(r []rune, i, end int) (string, int, bool) {
+	start := i
+	if c := r[i]; c == 'n' || c == 'N' {
+		i++
+	}
+	if c := grab(r, i, end); (c != 'q' && c != 'Q') || grab(r, i+1, end) != '\'' {
+		return "", start, false
+	}
+	if start != 0 && isWordRune(r[start-1]) {
+		return "", start, false
+	}
+	i += 2
+	c := grab(r, i, end)
+	switch {
+	case c == 0 || unicode.IsSpace(c):
+		return "", start, false
+	case c == '[':
+		c = ']'
+	case c == '{':
+		c = '}'
+	case c == '<':
+		c = '>'
+	case c == '(':
+		c = ')'
+	}
+	return string(c), i, true
+}
+
 // readMultilineComment finds the end of a multiline comment (ie, '*/').
 func readMultilineComment(r []rune, i, end int) (int, bool) {
 	i++
@@ -1,9 +1,13 @@
 package stmt
 
 import (
+	"io"
+	"os/user"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/xo/usql/env"
 )
 
 func TestGrab(t *testing.T) {
@@ -155,7 +159,7 @@ func TestReadString(t *testing.T) {
 		if c != '\'' && c != '"' && c != '`' {
 			t.Fatalf("test %d incorrect!", i)
 		}
-		pos, ok := readString(r, test.i+1, end, c, "")
+		pos, ok := readString(r, test.i+1, end, c, "", true)
 		if ok != test.ok {
 			t.Fatalf("test %d expected ok %t, got: %t", i, test.ok, ok)
 		}
@@ -175,6 +179,183 @@ func TestReadString(t *testing.T) {
 	}
 }
 
+func TestReadStringDialects(t *testing.T) {
+	tests := []struct {
+		s       string
+		quote   rune
+		tag     string
+		escapes bool
+		exp     string
+		ok      bool
+	}{
+		// standard strings
+		{`'foo\'`, '\'', "", false, `'foo\'`, true},
+		{`'foo\' bar'`, '\'', "", false, `'foo\'`, true},
+		{`'foo\\'`, '\'', "", false, `'foo\\'`, true},
+		{`'it''s;'`, '\'', "", false, `'it''s;'`, true},
+		// backslash escapes
+		{`'foo\' bar'`, '\'', "", true, `'foo\' bar'`, true},
+		{`'foo\\'`, '\'', "", true, `'foo\\'`, true},
+		{`'foo\'`, '\'', "", true, ``, false},
+		{`'\\\';'`, '\'', "", true, `'\\\';'`, true},
+		{`"foo\"`, '"', "", true, `"foo\"`, true},
+		// backticks
+		{"`foo`", '`', "", false, "`foo`", true},
+		{"`fo;o'`", '`', "", false, "`fo;o'`", true},
+		{"`foo", '`', "", false, ``, false},
+		// brackets
+		{`[foo]`, '[', "", false, `[foo]`, true},
+		{`[foo;bar]`, '[', "", false, `[foo;bar]`, true},
+		{`[foo]]bar]`, '[', "", false, `[foo]]bar]`, true},
+		{`[foo]]]`, '[', "", false, `[foo]]]`, true},
+		{`[it's]`, '[', "", false, `[it's]`, true},
+		{`[foo`, '[', "", false, ``, false},
+		{`[foo]]`, '[', "", false, ``, false},
+		// escape strings
+		{`E'foo'`, 'E', "", false, `E'foo'`, true},
+		{`E'it\'s;'`, 'E', "", false, `E'it\'s;'`, true},
+		{`E'it''s'`, 'E', "", false, `E'it''s'`, true},
+		{`E'\\'`, 'E', "", false, `E'\\'`, true},
+		{`E'foo\'`, 'E', "", false, ``, false},
+		// alternative quoted strings
+		{`q'[it's]'`, 'q', "]", false, `q'[it's]'`, true},
+		{`q'[a]b]'`, 'q', "]", false, `q'[a]b]'`, true},
+		{`q'{;}'`, 'q', "}", false, `q'{;}'`, true},
+		{`q'<'>'`, 'q', ">", false, `q'<'>'`, true},
+		{`q'(x)'`, 'q', ")", false, `q'(x)'`, true},
+		{`q'!it's!'`, 'q', "!", false, `q'!it's!'`, true},
+		{`nq'#a'b#'`, 'q', "#", false, `nq'#a'b#'`, true},
+		{`q'[it's'`, 'q', "]", false, ``, false},
+		{`q'[it's]`, 'q', "]", false, ``, false},
+	}
+	for i, test := range tests {
+		r := []rune(test.s)
+		start := strings.IndexRune(test.s, '\'') + 1
+		if test.quote != 'E' && test.quote != 'q' {
+			start = 1
+		}
+		if test.quote == 'q' {
+			start++
+		}
+		pos, ok := readString(r, start, len(r), test.quote, test.tag, test.escapes)
+		if ok != test.ok {
+			t.Fatalf("test %d expected ok %t, got: %t", i, test.ok, ok)
+		}
+		if !test.ok {
+			continue
+		}
+		if v := string(r[:pos+1]); v != test.exp {
+			t.Errorf("test %d expected %q, got: %q", i, test.exp, v)
+		}
+	}
+}
+
+func TestReadQQuote(t *testing.T) {
+	tests := []struct {
+		s   string
+		i   int
+		tag string
+		pos int
+		ok  bool
+	}{
+		{`q'[x]'`, 0, "]", 2, true},
+		{`Q'{x}'`, 0, "}", 2, true},
+		{`q'<x>'`, 0, ">", 2, true},
+		{`q'(x)'`, 0, ")", 2, true},
+		{`q'!x!'`, 0, "!", 2, true},
+		{`nq'[x]'`, 0, "]", 3, true},
+		{`NQ'#x#'`, 0, "#", 3, true},
+		{`select q'[x]'`, 7, "]", 9, true},
+		{`(q'[x]'`, 1, "]", 3, true},
+		{`q' x '`, 0, "", 0, false},
+		{`q'`, 0, "", 0, false},
+		{`q`, 0, "", 0, false},
+		{`N'x'`, 0, "", 0, false},
+		{`n'x'`, 0, "", 0, false},
+		{`xq'[x]'`, 1, "", 1, false},
+		{`anq'[x]'`, 1, "", 1, false},
+		{`q"x"`, 0, "", 0, false},
+	}
+	for i, test := range tests {
+		r := []rune(test.s)
+		tag, pos, ok := readQQuote(r, test.i, len(r))
+		if ok != test.ok {
+			t.Fatalf("test %d expected ok %t, got: %t", i, test.ok, ok)
+		}
+		if tag != test.tag {
+			t.Errorf("test %d expected tag %q, got: %q", i, test.tag, tag)
+		}
+		if pos != test.pos {
+			t.Errorf("test %d expected pos %d, got: %d", i, test.pos, pos)
+		}
+	}
+}
+
+func TestNextDialects(t *testing.T) {
+	u, err := user.Current()
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	unquote := env.Unquote(u, false, env.Vars{"a": "x"})
+	tests := []struct {
+		s     string
+		opt   Option
+		stmts []string
+		state string
+	}{
+		// backslash escapes
+		{`select 'it\'s;'; select :a;`, WithAllowBackslashEscapes(true), []string{`select 'it\'s;';`, `select x;`}, "="},
+		{`select 'C:\'; select :a;`, WithAllowBackslashEscapes(false), []string{`select 'C:\';`, `select x;`}, "="},
+		{`select 'C:\'; select :a;`, WithAllowBackslashEscapes(true), nil, "'"},
+		// backticks
+		{"select `a;b`, `:a` from t; select :a;", WithAllowBackticks(true), []string{"select `a;b`, `:a` from t;", "select x;"}, "="},
+		{"select `it's` from t;", WithAllowBackticks(true), []string{"select `it's` from t;"}, "="},
+		{"select `a\nb`;", WithAllowBackticks(true), []string{"select `a\nb`;"}, "="},
+		{"select `a;b`;", WithAllowBackticks(false), []string{"select `a;", "b`;"}, "="},
+		// brackets
+		{"select [a;b], [it's], [x]]y;] from t; select :a;", WithAllowBrackets(true), []string{"select [a;b], [it's], [x]]y;] from t;", "select x;"}, "="},
+		{"select [a\nb];", WithAllowBrackets(true), []string{"select [a\nb];"}, "="},
+		{"select [a", WithAllowBrackets(true), nil, `"`},
+		{"select [a;b];", WithAllowBrackets(false), []string{"select [a;", "b];"}, "="},
+		// escape strings
+		{`select E'it\'s;', e'\\'; select :a;`, WithAllowEStrings(true), []string{`select E'it\'s;', e'\\';`, `select x;`}, "="},
+		{`select E'it\'s;';`, WithAllowEStrings(false), []string{`select E'it\'s;`}, "'"},
+		{`select 'E\'; select :a;`, WithAllowEStrings(true), []string{`select 'E\';`, `select x;`}, "="},
+		{`select typE'\'; select :a;`, WithAllowEStrings(true), []string{`select typE'\';`, `select x;`}, "="},
+		{"select E'a\nb';", WithAllowEStrings(true), []string{"select E'a\nb';"}, "="},
+		// alternative quoted strings
+		{`select q'[it's;]' from dual; select :a;`, WithAllowQQuotes(true), []string{`select q'[it's;]' from dual;`, `select x;`}, "="},
+		{`select Q'{:a}', nq'!'!' from dual;`, WithAllowQQuotes(true), []string{`select Q'{:a}', nq'!'!' from dual;`}, "="},
+		{"select q'<a\n;>' from dual;", WithAllowQQuotes(true), []string{"select q'<a\n;>' from dual;"}, "="},
+		{"select q'<a\n;", WithAllowQQuotes(true), nil, "'"},
+		{`select N'it''s;', n; select :a;`, WithAllowQQuotes(true), []string{`select N'it''s;', n;`, `select x;`}, "="},
+		{`select q'[it's;]';`, WithAllowQQuotes(false), []string{`select q'[it's;`}, "'"},
+	}
+	for i, test := range tests {
+		b := New(sp(test.s, "\n"), test.opt)
+		var stmts []string
+		for {
+			_, _, err := b.Next(unquote)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("test %d expected no error, got: %v", i, err)
+			}
+			if b.Ready() {
+				stmts = append(stmts, b.String())
+				b.Reset(nil)
+			}
+		}
+		if !reflect.DeepEqual(stmts, test.stmts) {
+			t.Errorf("test %d expected statements %s, got: %s", i, jj(test.stmts), jj(stmts))
+		}
+		if st := b.State(); st != test.state {
+			t.Errorf("test %d expected state %q, got: %q", i, test.state, st)
+		}
+	}
+}
+
 func TestReadCommand(t *testing.T) {
 	tests := []struct {
 		s   string
@@ -3,6 +3,8 @@ package stmt
 
 import (
 	"bytes"
+	"io"
+	"unicode"
 )
 
 // MinCapIncrease is the minimum amount by which to grow a Stmt.Buf.
@@ -51,6 +53,31 @@ type Stmt struct {
 	allowCComments bool
 	// allowHashComments allows hash comments (ie, # ... )
 	allowHashComments bool
+	// allowBackslashEscapes allows backslash escapes in single quoted strings
+	// (ie, 'it\'s')
+	allowBackslashEscapes bool
+	// allowBackticks allows backtick quoted identifiers (ie, `name`)
+	allowBackticks bool
+	// allowBrackets allows bracket quoted identifiers (ie, [name])
+	allowBrackets bool
+	// allowQQuotes allows alternative quoted strings (ie, q'[ ... ]')
+	allowQQuotes bool
+	// allowEStrings allows escape strings (ie, E'\n')
+	allowEStrings bool
+	// batchSeparator is the batch separator (ie, GO) that terminates a
+	// statement, instead of semicolons, when alone on a line.
+	batchSeparator string
+	// allowPLSQL allows PL/SQL blocks, terminated by a line containing only a
+	// slash (ie, /)
+	allowPLSQL bool
+	// allowDelimiter allows the delimiter command (ie, DELIMITER $$) to change
+	// the statement terminator
+	allowDelimiter bool
+	// delimiter is the custom statement terminator, if any
+	delimiter string
+	// allowBlocks allows compound statement blocks (ie, BEGIN ... END) in
+	// CREATE TRIGGER, PROCEDURE, and FUNCTION statements
+	allowBlocks bool
 	// Buf is the statement buffer
 	Buf []rune
 	// Len is the current len of any statement in Buf.
@@ -59,18 +86,24 @@ type Stmt struct {
 	Prefix string
 	// Vars is the list of encountered variables.
 	Vars []*Var
+	// Repeat is the number of times to execute the statement, as given after
+	// the batch separator (ie, GO 5), or 0 when not given.
+	Repeat int
 	// r is the unprocessed runes.
 	r []rune
 	// rlen is the number of unprocessed runes.
 	rlen int
 	// quote indicates currently parsing a quoted string.
 	quote rune
-	// quoteDollarTag is the parsed tag of a dollar quoted string
+	// quoteDollarTag is the parsed tag of a dollar quoted string, or the
+	// closing delimiter of an alternative quoted string
 	quoteDollarTag string
 	// multilineComment is state of multiline comment processing
 	multilineComment bool
 	// balanceCount is the balanced paren count
 	balanceCount int
+	// blockDepth is the compound statement block depth
+	blockDepth int
 	// ready indicates that a complete statement has been parsed
 	ready bool
 }
@@ -135,13 +168,13 @@ func (b *Stmt) Ready() bool {
 // Reset resets the statement buffer.
 func (b *Stmt) Reset(r []rune) {
 	// reset buf
-	b.Buf, b.Len, b.Prefix, b.Vars = nil, 0, "", nil
+	b.Buf, b.Len, b.Prefix, b.Vars, b.Repeat = nil, 0, "", nil, 0
 	// quote state
 	b.quote, b.quoteDollarTag = 0, ""
 	// multicomment state
 	b.multilineComment = false
 	// balance state
-	b.balanceCount = 0
+	b.balanceCount, b.blockDepth = 0, 0
 	// ready state
 	b.ready = false
 	if r != nil {
@@ -192,10 +225,41 @@ func (b *Stmt) Next(unquote func(string, bool) (bool, string, error)) (string, s
 	// no runes to process, grab more
 	if b.rlen == 0 {
 		b.r, err = b.f()
+		// end of input terminates the last batch
+		if err == io.EOF && b.batchSeparator != "" && b.Len != 0 && b.quote == 0 && !b.multilineComment {
+			b.ready = true
+			return "", "", nil
+		}
 		if err != nil {
 			return "", "", err
 		}
 		b.rlen = len(b.r)
+		// batch separator alone on a line terminates the statement
+		if b.batchSeparator != "" && b.quote == 0 && !b.multilineComment {
+			if n, ok := readBatchSeparator(b.r, b.rlen, b.batchSeparator); ok {
+				b.r, b.rlen = b.r[:0], 0
+				if b.Len != 0 {
+					b.ready, b.Repeat = true, n
+				}
+				return "", "", nil
+			}
+		}
+		// delimiter command changes the statement terminator
+		if b.allowDelimiter && b.Len == 0 && b.quote == 0 && !b.multilineComment {
+			if delim, ok := readDelimiter(b.r, b.rlen); ok {
+				b.r, b.rlen = b.r[:0], 0
+				if b.delimiter = delim; delim == ";" {
+					b.delimiter = ""
+				}
+				return "", "", nil
+			}
+		}
+		// slash alone on a line executes the statement
+		if b.allowPLSQL && b.quote == 0 && !b.multilineComment && isSlashLine(b.r, b.rlen) {
+			b.r, b.rlen = b.r[:0], 0
+			b.ready = true
+			return "", "", nil
+		}
 	}
 	var cmd, params string
 	var ok bool
@@ -207,7 +271,7 @@ parse:
 		switch {
 		// find end of string
 		case b.quote != 0:
-			i, ok = readString(b.r, i, b.rlen, b.quote, b.quoteDollarTag)
+			i, ok = readString(b.r, i, b.rlen, b.quote, b.quoteDollarTag, b.allowBackslashEscapes)
 			if ok {
 				b.quote, b.quoteDollarTag = 0, ""
 			}
@@ -215,9 +279,31 @@ parse:
 		case b.multilineComment:
 			i, ok = readMultilineComment(b.r, i, b.rlen)
 			b.multilineComment = !ok
+		// custom delimiter, removed from the statement
+		case b.delimiter != "" && hasPrefix(b.r, i, b.rlen, b.delimiter):
+			b.r = append(b.r[:i], b.r[i+len([]rune(b.delimiter)):]...)
+			b.rlen = len(b.r)
+			b.ready = b.Len != 0 || !isEmptyLine(b.r, 0, i)
+			break parse
 		// start of single or double quoted string
 		case c == '\'' || c == '"':
 			b.quote = c
+		// start of backtick quoted identifier
+		case b.allowBackticks && c == '`':
+			b.quote = c
+		// start of bracket quoted identifier
+		case b.allowBrackets && c == '[':
+			b.quote = c
+		// start of escape string (postgres)
+		case b.allowEStrings && (c == 'E' || c == 'e') && next == '\'' && (i == 0 || !isWordRune(b.r[i-1])):
+			b.quote = 'E'
+			i++
+		// start of alternative quoted string (oracle)
+		case b.allowQQuotes && (c == 'Q' || c == 'q' || c == 'N' || c == 'n') && (next == '\'' || next == 'Q' || next == 'q'):
+			var tag string
+			if tag, i, ok = readQQuote(b.r, i, b.rlen); ok {
+				b.quote, b.quoteDollarTag = 'q', tag
+			}
 		// start of dollar quoted string literal (postgres)
 		case b.allowDollar && c == '$':
 			var id string
@@ -287,8 +373,17 @@ parse:
 			b.r = append(b.r[:i], b.r[pend:]...)
 			b.rlen = len(b.r)
 			break parse
+		// start or end of compound statement block
+		case b.allowBlocks && unicode.IsLetter(c) && (i == 0 || !isWordRune(b.r[i-1])):
+			i = b.readBlock(i) - 1
+		// semicolons do not terminate compound statement blocks
+		case c == ';' && b.blockDepth != 0:
+		// semicolons do not terminate PL/SQL blocks
+		case c == ';' && b.allowPLSQL && b.inPLSQLBlock(i):
+		// semicolons do not terminate batches
+		case c == ';' && b.batchSeparator != "":
 		// terminated
-		case c == ';':
+		case c == ';' && b.delimiter == "":
 			b.ready = true
 			i++
 			break parse
@@ -330,6 +425,70 @@ parse:
 	return cmd, params, nil
 }
 
+// ReadLine reads the next line from the rune source, discarding any remaining
+// whitespace following the last statement. Used to read data following a
+// statement, such as the data for COPY ... FROM STDIN.
+func (b *Stmt) ReadLine() ([]rune, error) {
+	if b.rlen != 0 && !isEmptyLine(b.r, 0, b.rlen) {
+		r := b.r
+		b.r, b.rlen = nil, 0
+		return r, nil
+	}
+	b.r, b.rlen = nil, 0
+	return b.f()
+}
+
+// prefixAt returns the prefix of the statement, including the unprocessed
+// runes up to i.
+func (b *Stmt) prefixAt(i int) string {
+	r := b.r[:i]
+	if b.Len != 0 {
+		r = append(append(append(make([]rune, 0, b.Len+1+i), b.Buf[:b.Len]...), lineend...), r...)
+	}
+	return findPrefix(r, prefixCount, b.allowCComments, b.allowHashComments, b.allowMultilineComments)
+}
+
+// inPLSQLBlock returns true when the statement, including the unprocessed
+// runes up to i, is a PL/SQL block.
+func (b *Stmt) inPLSQLBlock(i int) bool {
+	return IsPLSQLBlock(b.prefixAt(i))
+}
+
+// readBlock reads the word at i, tracking the depth of compound statement
+// blocks, returning the end of the word.
+//
+// BEGIN and CASE start a block, when within a block or a statement that can
+// contain blocks (ie, CREATE TRIGGER). END closes the block, except when
+// followed by IF, LOOP, REPEAT, or WHILE (ie, END IF).
+func (b *Stmt) readBlock(i int) int {
+	word, end := readWord(b.r, i, b.rlen)
+	if word != "BEGIN" && word != "CASE" && word != "END" {
+		return end
+	}
+	// grab next word on the line
+	next, nextEnd := "", end
+	if j, ok := findNonSpace(b.r, end, b.rlen); ok {
+		next, nextEnd = readWord(b.r, j, b.rlen)
+	}
+	switch {
+	case word == "END" && b.blockDepth != 0:
+		switch next {
+		case "IF", "LOOP", "REPEAT", "WHILE":
+		case "CASE":
+			b.blockDepth--
+			return nextEnd
+		default:
+			b.blockDepth--
+		}
+	case word == "END":
+	// transaction statements (ie, BEGIN TRANSACTION)
+	case word == "BEGIN" && (next == "TRAN" || next == "TRANSACTION" || next == "WORK" || next == "DISTRIBUTED" || next == "DEFERRED" || next == "IMMEDIATE" || next == "EXCLUSIVE"):
+	case b.blockDepth != 0 || IsBlockStmt(b.prefixAt(i)):
+		b.blockDepth++
+	}
+	return end
+}
+
 // Append appends r to b.Buf separated by sep when b.Buf is not already empty.
 //
 // Dynamically grows b.Buf as necessary to accommodate r and the separator.
@@ -369,6 +528,10 @@ func (b *Stmt) AppendString(s, sep string) {
 // State returns a string representing the state of statement parsing.
 func (b *Stmt) State() string {
 	switch {
+	case b.quote == 'E' || b.quote == 'q':
+		return "'"
+	case b.quote == '`' || b.quote == '[':
+		return `"`
 	case b.quote != 0:
 		return string(b.quote)
 	case b.multilineComment:
@@ -377,6 +540,8 @@ func (b *Stmt) State() string {
 		return "("
 	case b.Len != 0:
 		return "-"
+	case b.delimiter != "":
+		return b.delimiter
 	}
 	return "="
 }
@@ -415,3 +580,79 @@ func WithAllowHashComments(enable bool) Option {
 		b.allowHashComments = enable
 	}
 }
+
+// WithBatchSeparator is a statement buffer option to set the batch separator
+// (ie, GO) that terminates a statement when alone on a line, optionally
+// followed by a repeat count (ie, GO 5). Semicolons do not terminate
+// statements of batches, and the end of input terminates the last batch.
+func WithBatchSeparator(sep string) Option {
+	return func(b *Stmt) {
+		b.batchSeparator = sep
+	}
+}
+
+// WithAllowPLSQL is a statement buffer option to set allowing PL/SQL blocks
+// (ie, BEGIN ... END;), that are terminated only by a line containing a slash
+// (ie, /). A slash alone on a line also executes the statement buffer.
+func WithAllowPLSQL(enable bool) Option {
+	return func(b *Stmt) {
+		b.allowPLSQL = enable
+	}
+}
+
+// WithAllowDelimiter is a statement buffer option to set allowing the
+// delimiter command (ie, DELIMITER $$) to change the statement terminator.
+func WithAllowDelimiter(enable bool) Option {
+	return func(b *Stmt) {
+		b.allowDelimiter = enable
+	}
+}
+
+// WithAllowBlocks is a statement buffer option to set allowing compound
+// statement blocks (ie, BEGIN ... END) in CREATE TRIGGER, PROCEDURE, and
+// FUNCTION statements.
+func WithAllowBlocks(enable bool) Option {
+	return func(b *Stmt) {
+		b.allowBlocks = enable
+	}
+}
+
+// WithAllowBackslashEscapes is a statement buffer option to set allowing
+// backslash escapes in single quoted strings (ie, 'it\'s').
+func WithAllowBackslashEscapes(enable bool) Option {
+	return func(b *Stmt) {
+		b.allowBackslashEscapes = enable
+	}
+}
+
+// WithAllowBackticks is a statement buffer option to set allowing backtick
+// quoted identifiers (ie, `name`).
+func WithAllowBackticks(enable bool) Option {
+	return func(b *Stmt) {
+		b.allowBackticks = enable
+	}
+}
+
+// WithAllowBrackets is a statement buffer option to set allowing bracket
+// quoted identifiers (ie, [name]).
+func WithAllowBrackets(enable bool) Option {
+	return func(b *Stmt) {
+		b.allowBrackets = enable
+	}
+}
+
+// WithAllowQQuotes is a statement buffer option to set allowing alternative
+// quoted strings (ie, q'[it's]').
+func WithAllowQQuotes(enable bool) Option {
+	return func(b *Stmt) {
+		b.allowQQuotes = enable
+	}
+}
+
+// WithAllowEStrings is a statement buffer option to set allowing escape
+// strings (ie, E'it\'s').
+func WithAllowEStrings(enable bool) Option {
+	return func(b *Stmt) {
+		b.allowEStrings = enable
+	}
+}
@@ -276,6 +276,255 @@ func TestEmptyVariablesRawString(t *testing.T) {
 	}
 }
 
+func TestNextBatchSeparator(t *testing.T) {
+	tests := []struct {
+		s      string
+		stmts  []string
+		repeat []int
+	}{
+		{"select 1\nGO", []string{"select 1"}, []int{1}},
+		{"select 1\ngo 5", []string{"select 1"}, []int{5}},
+		{"print 'a'\n  Go  -- comment\nprint 'b'\nGO 2\n", []string{"print 'a'", "print 'b'"}, []int{1, 2}},
+		{"GO\nGO 3\nselect 1;", []string{"select 1;"}, []int{0}},
+		{"select 'a\nGO\n';", []string{"select 'a\nGO\n';"}, []int{0}},
+		{"select /*\nGO\n*/ 1\nGO", []string{"select /*\nGO\n*/ 1"}, []int{1}},
+		{"select 1 as go\ngoto x\nGO 0\nGO", []string{"select 1 as go\ngoto x\nGO 0"}, []int{1}},
+		{"declare @x int;\nset @x = 1;\nselect @x;\nGO", []string{"declare @x int;\nset @x = 1;\nselect @x;"}, []int{1}},
+		{"select 1; select 2\nGO\nselect 3;\nselect 4;", []string{"select 1; select 2", "select 3;\nselect 4;"}, []int{1, 0}},
+	}
+	for i, test := range tests {
+		b := New(sp(test.s, "\n"), WithAllowMultilineComments(true), WithBatchSeparator("GO"))
+		var stmts []string
+		var repeat []int
+		for {
+			_, _, err := b.Next(func(string, bool) (bool, string, error) { return false, "", nil })
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("test %d expected no error, got: %v", i, err)
+			}
+			if b.Ready() {
+				stmts, repeat = append(stmts, b.String()), append(repeat, b.Repeat)
+				b.Reset(nil)
+			}
+		}
+		if !reflect.DeepEqual(stmts, test.stmts) {
+			t.Errorf("test %d expected statements %s, got: %s", i, jj(test.stmts), jj(stmts))
+		}
+		if !reflect.DeepEqual(repeat, test.repeat) {
+			t.Errorf("test %d expected repeat %v, got: %v", i, test.repeat, repeat)
+		}
+	}
+}
+
+func TestNextPLSQL(t *testing.T) {
+	tests := []struct {
+		s     string
+		stmts []string
+	}{
+		{"select 1 from dual;", []string{"select 1 from dual;"}},
+		{"begin\n  null;\nend;\n/", []string{"begin\n  null;\nend;"}},
+		{"BEGIN NULL; END;\n /  \nselect 1 from dual;", []string{"BEGIN NULL; END;", "select 1 from dual;"}},
+		{"declare\n  x number := 1;\nbegin\n  x := x / 2;\nend;\n/", []string{"declare\n  x number := 1;\nbegin\n  x := x / 2;\nend;"}},
+		{"create or replace package body p as\n  procedure a is begin null; end;\nend p;\n/", []string{"create or replace package body p as\n  procedure a is begin null; end;\nend p;"}},
+		{"/* c */ CREATE EDITIONABLE TRIGGER t\nBEFORE INSERT ON x\nBEGIN NULL; END;\n/", []string{"/* c */ CREATE EDITIONABLE TRIGGER t\nBEFORE INSERT ON x\nBEGIN NULL; END;"}},
+		{"begin\n  x := '\n/\n';\nend;\n/", []string{"begin\n  x := '\n/\n';\nend;"}},
+		{"create table t (x number);\n/", []string{"create table t (x number);", ""}},
+	}
+	for i, test := range tests {
+		b := New(sp(test.s, "\n"), WithAllowMultilineComments(true), WithAllowPLSQL(true))
+		var stmts []string
+		for {
+			_, _, err := b.Next(func(string, bool) (bool, string, error) { return false, "", nil })
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("test %d expected no error, got: %v", i, err)
+			}
+			if b.Ready() {
+				stmts = append(stmts, b.String())
+				b.Reset(nil)
+			}
+		}
+		if !reflect.DeepEqual(stmts, test.stmts) {
+			t.Errorf("test %d expected statements %s, got: %s", i, jj(test.stmts), jj(stmts))
+		}
+	}
+}
+
+func TestNextDelimiter(t *testing.T) {
+	tests := []struct {
+		s     string
+		stmts []string
+		state string
+	}{
+		{"DELIMITER $$\nselect 1; select 2$$", []string{"select 1; select 2"}, "$$"},
+		{"delimiter //\ncreate procedure p()\nbegin\n  select 1;\nend//\ndelimiter ;\nselect 2;", []string{"create procedure p()\nbegin\n  select 1;\nend", "select 2;"}, "="},
+		{"DELIMITER ;;\nselect 1;; select 2;;\n;;", []string{"select 1", "select 2"}, ";;"},
+		{"DELIMITER $$\nselect '$$'$$", []string{"select '$$'"}, "$$"},
+		{"select 1;\nDELIMITER $$\nselect 2$$", []string{"select 1;", "select 2"}, "$$"},
+		{"select 1\nDELIMITER $$\n;", []string{"select 1\nDELIMITER $$\n;"}, "="},
+	}
+	for i, test := range tests {
+		b := New(sp(test.s, "\n"), WithAllowDelimiter(true))
+		var stmts []string
+		for {
+			_, _, err := b.Next(func(string, bool) (bool, string, error) { return false, "", nil })
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("test %d expected no error, got: %v", i, err)
+			}
+			if b.Ready() {
+				stmts = append(stmts, b.String())
+				b.Reset(nil)
+			}
+		}
+		if !reflect.DeepEqual(stmts, test.stmts) {
+			t.Errorf("test %d expected statements %s, got: %s", i, jj(test.stmts), jj(stmts))
+		}
+		if st := b.State(); st != test.state {
+			t.Errorf("test %d expected state %q, got: %q", i, test.state, st)
+		}
+	}
+}
+
+func TestNextBlocks(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		stmts []string
+		opts  []Option
+	}{
+		{
+			"sqlite trigger",
+			"CREATE TRIGGER t AFTER INSERT ON a\nBEGIN\n  UPDATE b SET x = 1;\n  DELETE FROM c;\nEND;\nselect 1;",
+			[]string{"CREATE TRIGGER t AFTER INSERT ON a\nBEGIN\n  UPDATE b SET x = 1;\n  DELETE FROM c;\nEND;", "select 1;"},
+			nil,
+		},
+		{
+			"sqlite temp trigger with case",
+			"create temp trigger t before update on a begin select case when new.x < 0 then raise(abort, 'neg') end; end; select 2;",
+			[]string{"create temp trigger t before update on a begin select case when new.x < 0 then raise(abort, 'neg') end; end;", "select 2;"},
+			nil,
+		},
+		{
+			"sqlite transaction",
+			"BEGIN;\ninsert into a values (1);\nEND;\nbegin transaction; commit;",
+			[]string{"BEGIN;", "insert into a values (1);", "END;", "begin transaction;", "commit;"},
+			nil,
+		},
+		{
+			"sqlite begin end words",
+			"select begin_date, \"end\" from a; select x_end from b;",
+			[]string{"select begin_date, \"end\" from a;", "select x_end from b;"},
+			nil,
+		},
+		{
+			"mysql procedure",
+			"CREATE DEFINER=`root`@`localhost` PROCEDURE p(IN n INT)\nBEGIN\n  IF n > 0 THEN\n    SELECT 1;\n  END IF;\n  CASE n WHEN 1 THEN SELECT 2; ELSE BEGIN END; END CASE;\n  l: LOOP\n    LEAVE l;\n  END LOOP l;\nEND;\nselect 3;",
+			[]string{"CREATE DEFINER=`root`@`localhost` PROCEDURE p(IN n INT)\nBEGIN\n  IF n > 0 THEN\n    SELECT 1;\n  END IF;\n  CASE n WHEN 1 THEN SELECT 2; ELSE BEGIN END; END CASE;\n  l: LOOP\n    LEAVE l;\n  END LOOP l;\nEND;", "select 3;"},
+			[]Option{WithAllowHashComments(true)},
+		},
+		{
+			"mysql function without block",
+			"CREATE FUNCTION f() RETURNS INT DETERMINISTIC RETURN CASE WHEN 1 THEN 1 ELSE 0 END; select 4;",
+			[]string{"CREATE FUNCTION f() RETURNS INT DETERMINISTIC RETURN CASE WHEN 1 THEN 1 ELSE 0 END;", "select 4;"},
+			nil,
+		},
+		{
+			"sqlserver procedure",
+			"CREATE OR ALTER PROCEDURE dbo.p AS\nBEGIN\n  BEGIN TRANSACTION;\n  BEGIN TRY\n    UPDATE a SET x = 1;\n    COMMIT;\n  END TRY\n  BEGIN CATCH\n    ROLLBACK;\n  END CATCH;\nEND;\nEXEC dbo.p;",
+			[]string{"CREATE OR ALTER PROCEDURE dbo.p AS\nBEGIN\n  BEGIN TRANSACTION;\n  BEGIN TRY\n    UPDATE a SET x = 1;\n    COMMIT;\n  END TRY\n  BEGIN CATCH\n    ROLLBACK;\n  END CATCH;\nEND;", "EXEC dbo.p;"},
+			[]Option{WithAllowMultilineComments(true)},
+		},
+		{
+			"sqlserver function with comments",
+			"CREATE FUNCTION dbo.f() RETURNS INT AS /* begin */\nBEGIN -- end;\n  RETURN 1;\nEND;",
+			[]string{"CREATE FUNCTION dbo.f() RETURNS INT AS /* begin */\nBEGIN -- end;\n  RETURN 1;\nEND;"},
+			[]Option{WithAllowMultilineComments(true)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := New(sp(test.s, "\n"), append(test.opts, WithAllowBlocks(true))...)
+			var stmts []string
+			for {
+				_, _, err := b.Next(func(string, bool) (bool, string, error) { return false, "", nil })
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				if b.Ready() {
+					stmts = append(stmts, b.String())
+					b.Reset(nil)
+				}
+			}
+			if !reflect.DeepEqual(stmts, test.stmts) {
+				t.Errorf("expected statements %s, got: %s", jj(test.stmts), jj(stmts))
+			}
+		})
+	}
+}
+
+func TestReadLine(t *testing.T) {
+	tests := []struct {
+		s     string
+		stmt  string
+		lines []string
+		next  string
+	}{
+		{"copy t from stdin;\n1\ta\n2\tb\n\\.\nselect 1;", "copy t from stdin;", []string{"1\ta", "2\tb", `\.`}, "select 1;"},
+		{"copy t from stdin;  \n1\n\\.", "copy t from stdin;", []string{"1", `\.`}, ""},
+		{"copy t from stdin; 1\n\\.", "copy t from stdin;", []string{" 1", `\.`}, ""},
+	}
+	for i, test := range tests {
+		b := New(sp(test.s, "\n"))
+		for !b.Ready() {
+			if _, _, err := b.Next(func(string, bool) (bool, string, error) { return false, "", nil }); err != nil {
+				t.Fatalf("test %d expected no error, got: %v", i, err)
+			}
+		}
+		if s := b.String(); s != test.stmt {
+			t.Errorf("test %d expected statement %q, got: %q", i, test.stmt, s)
+		}
+		b.Reset(nil)
+		var lines []string
+		for range test.lines {
+			r, err := b.ReadLine()
+			if err != nil {
+				t.Fatalf("test %d expected no error, got: %v", i, err)
+			}
+			lines = append(lines, string(r))
+		}
+		if !reflect.DeepEqual(lines, test.lines) {
+			t.Errorf("test %d expected lines %s, got: %s", i, jj(test.lines), jj(lines))
+		}
+		var next string
+		for {
+			_, _, err := b.Next(func(string, bool) (bool, string, error) { return false, "", nil })
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("test %d expected no error, got: %v", i, err)
+			}
+			if b.Ready() {
+				next = b.String()
+				b.Reset(nil)
+			}
+		}
+		if next != test.next {
+			t.Errorf("test %d expected next statement %q, got: %q", i, test.next, next)
+		}
+	}
+}
+
 // cc combines commands with params.
 func cc(cmds []string, params []string) []string {
 	if len(cmds) == 0 {
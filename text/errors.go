@@ -69,4 +69,10 @@ var (
 	ErrNotSupported = errors.New("not supported")
 	// ErrWrongNumberOfArguments is the wrong number of arguments error.
 	ErrWrongNumberOfArguments = errors.New("wrong number of arguments")
+	// ErrNotListening is the not listening error.
+	ErrNotListening = errors.New("not listening on any channel")
+	// ErrNoNotification is the no notification received error.
+	ErrNoNotification = errors.New("no notification received")
+	// ErrInvalidWaitTimeout is the invalid wait timeout error.
+	ErrInvalidWaitTimeout = errors.New("invalid wait timeout")
 )
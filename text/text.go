@@ -84,9 +84,14 @@ var (
 	InvalidValue         = `invalid -%s value %q: %s`
 	NotSupportedByDriver = `%s not supported by %s driver`
 	RelationNotFound     = `Did not find any relation named "%s".`
+	FunctionNotFound     = `Did not find any function named "%s".`
 	InvalidOption        = `invalid option %q`
 	NotificationReceived = `Asynchronous notification %q %sreceived from server process with PID %d.`
 	NotificationPayload  = `with payload %q `
+	CopyInInstructions   = "Enter data to be copied followed by a newline.\nEnd with a backslash and a period on a line by itself, or an EOF signal."
+	CopyInPrompt         = `>> `
+	WarningMessage       = `%s (Code %d): %s`
+	RowsAffected         = `(%d rows affected)`
 )
 
 func init() {